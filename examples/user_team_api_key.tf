@@ -1,17 +1,31 @@
 ## Teams
 
 resource "ns1_team" "example_team" {
-  name                       = "Example Team"
-  dns_manage_zones           = false
-  dns_view_zones             = true
-  dns_zones_allow_by_default = false
-  dns_zones_allow            = ["example.com"]
+  name = "Example Team"
+
   # ....... all permissions listed on G
-  account_manage_ip_whitelist = true
-  monitoring_manage_lists     = true
-  monitoring_manage_jobs      = true
-  monitoring_view_jobs        = true
-  security_manage_global_2fa  = true
+  permissions {
+    dns {
+      manage_zones           = false
+      view_zones             = true
+      zones_allow_by_default = false
+      zones_allow            = ["example.com"]
+    }
+
+    account {
+      manage_ip_whitelist = true
+    }
+
+    monitoring {
+      manage_lists = true
+      manage_jobs  = true
+      view_jobs    = true
+    }
+
+    security {
+      manage_global_2fa = true
+    }
+  }
 }
 
 
@@ -36,24 +50,41 @@ resource "ns1_user" "example_admin_user" {
   notify = {
     "billing" = true
   }
-  dns_view_zones                  = true
-  dns_manage_zones                = true
-  dns_zones_allow_by_default      = true
-  data_push_to_datafeeds          = true
-  data_manage_datasources         = true
-  data_manage_datafeeds           = true
-  account_manage_users            = true
-  account_manage_payment_methods  = true
-  account_manage_teams            = true
-  account_manage_apikeys          = true
-  account_manage_account_settings = true
-  account_view_activity_log       = true
-  account_view_invoices           = true
-  account_manage_ip_whitelist     = true
-  monitoring_manage_lists         = true
-  monitoring_manage_jobs          = true
-  monitoring_view_jobs            = true
-  security_manage_global_2fa      = true
+
+  permissions {
+    dns {
+      view_zones             = true
+      manage_zones           = true
+      zones_allow_by_default = true
+    }
+
+    data {
+      push_to_datafeeds  = true
+      manage_datasources = true
+      manage_datafeeds   = true
+    }
+
+    account {
+      manage_users            = true
+      manage_payment_methods  = true
+      manage_teams            = true
+      manage_apikeys          = true
+      manage_account_settings = true
+      view_activity_log       = true
+      view_invoices           = true
+      manage_ip_whitelist     = true
+    }
+
+    monitoring {
+      manage_lists = true
+      manage_jobs  = true
+      view_jobs    = true
+    }
+
+    security {
+      manage_global_2fa = true
+    }
+  }
 }
 
 # Read only user with IP whitelist
@@ -64,35 +95,60 @@ resource "ns1_user" "example_whitelist_user" {
   notify = {
     "billing" = true
   }
-  ip_whitelist                    = ["1.1.1.1", "2.2.2.2"]
-  dns_view_zones                  = true
-  dns_manage_zones                = false
-  dns_zones_allow_by_default      = false
-  data_push_to_datafeeds          = false
-  data_manage_datasources         = false
-  data_manage_datafeeds           = false
-  account_manage_users            = false
-  account_manage_payment_methods  = false
-  account_manage_teams            = false
-  account_manage_apikeys          = false
-  account_manage_account_settings = false
-  account_view_activity_log       = false
-  account_view_invoices           = false
-  account_manage_ip_whitelist     = false
-  monitoring_manage_lists         = false
-  monitoring_manage_jobs          = false
-  monitoring_view_jobs            = true
-  security_manage_global_2fa      = false
+  ip_whitelist = ["1.1.1.1", "2.2.2.2"]
+
+  permissions {
+    dns {
+      view_zones             = true
+      manage_zones           = false
+      zones_allow_by_default = false
+    }
+
+    data {
+      push_to_datafeeds  = false
+      manage_datasources = false
+      manage_datafeeds   = false
+    }
+
+    account {
+      manage_users            = false
+      manage_payment_methods  = false
+      manage_teams            = false
+      manage_apikeys          = false
+      manage_account_settings = false
+      view_activity_log       = false
+      view_invoices           = false
+      manage_ip_whitelist     = false
+    }
+
+    monitoring {
+      manage_lists = false
+      manage_jobs  = false
+      view_jobs    = true
+    }
+
+    security {
+      manage_global_2fa = false
+    }
+  }
 }
 
 
 ## API keys
 resource "ns1_apikey" "example" {
-  name                       = "Example API Key from Terraform"
-  monitoring_manage_lists    = true
-  monitoring_manage_jobs     = true
-  monitoring_view_jobs       = true
-  security_manage_global_2fa = true
+  name = "Example API Key from Terraform"
+
+  permissions {
+    monitoring {
+      manage_lists = true
+      manage_jobs  = true
+      view_jobs    = true
+    }
+
+    security {
+      manage_global_2fa = true
+    }
+  }
 }
 
 
@@ -104,31 +160,46 @@ resource "ns1_apikey" "example" {
 #teams - (Required) The teams that the user belongs to.
 #ip_whitelist - (Optional) Array of IP addresses/networks to which to grant the user access.
 #ip_whitelist_strict - (Optional) Set to true to restrict access to only those IP addresses and networks listed in the ip_whitelist field.
-#dns_view_zones - (Optional) Whether the user can view the accounts zones.
-#dns_manage_zones - (Optional) Whether the user can modify the accounts zones.
-#dns_zones_allow_by_default - (Optional) If true, enable the dns_zones_allow list, otherwise enable the dns_zones_deny list.
-#dns_zones_allow - (Optional) List of zones that the user may access.
-#dns_zones_deny - (Optional) List of zones that the user may not access.
-#data_push_to_datafeeds - (Optional) Whether the user can publish to data feeds.
-#data_manage_datasources - (Optional) Whether the user can modify data sources.
-#data_manage_datafeeds - (Optional) Whether the user can modify data feeds.
-#account_manage_users - (Optional) Whether the user can modify account users.
-#account_manage_payment_methods - (Optional) Whether the user can modify account payment methods.
-#account_manage_plan - (Deprecated) No longer in use.
-#account_manage_teams - (Optional) Whether the user can modify other teams in the account.
-#account_manage_apikeys - (Optional) Whether the user can modify account apikeys.
-#account_manage_account_settings - (Optional) Whether the user can modify account settings.
-#account_view_activity_log - (Optional) Whether the user can view activity logs.
-#account_view_invoices - (Optional) Whether the user can view invoices.
-#account_manage_ip_whitelist - (Optional) Whether the user can manage ip whitelist.
-#monitoring_manage_lists - (Optional) Whether the user can modify notification lists.
-#monitoring_manage_jobs - (Optional) Whether the user can create, update, and delete monitoring jobs.
-#monitoring_create_jobs - (Optional) Whether the user can create monitoring jobs when manage_jobs is not set to true.
-#monitoring_update_jobs - (Optional) Whether the user can update monitoring jobs when manage_jobs is not set to true.
-#monitoring_delete_jobs - (Optional) Whether the user can delete monitoring jobs when manage_jobs is not set to true.
-#monitoring_view_jobs - (Optional) Whether the user can view monitoring jobs.
-#security_manage_global_2fa - (Optional) Whether the user can manage global two factor authentication.
-#security_manage_active_directory - (Optional) Whether the user can manage global active directory. Only relevant for the DDI product.
-#redirects_manage_redirects - (Optional) Whether the user can manage redirects.
-#insights_view_insights - (Optional) Whether the user can view DNS insights
-#insights_manage_insights - (Optional) Whether the user can manage DNS insights
+#permissions.dns.view_zones - (Optional) Whether the user can view the accounts zones.
+#permissions.dns.manage_zones - (Optional) Whether the user can modify the accounts zones.
+#permissions.dns.zones_allow_by_default - (Optional) If true, enable the zones_allow list, otherwise enable the zones_deny list.
+#permissions.dns.zones_allow - (Optional) List of zones that the user may access.
+#permissions.dns.zones_deny - (Optional) List of zones that the user may not access.
+#permissions.data.push_to_datafeeds - (Optional) Whether the user can publish to data feeds.
+#permissions.data.manage_datasources - (Optional) Whether the user can modify data sources.
+#permissions.data.manage_datafeeds - (Optional) Whether the user can modify data feeds.
+#permissions.account.manage_users - (Optional) Whether the user can modify account users.
+#permissions.account.manage_payment_methods - (Optional) Whether the user can modify account payment methods.
+#permissions.account.manage_plan - (Deprecated) No longer in use.
+#permissions.account.manage_teams - (Optional) Whether the user can modify other teams in the account.
+#permissions.account.manage_apikeys - (Optional) Whether the user can modify account apikeys.
+#permissions.account.manage_account_settings - (Optional) Whether the user can modify account settings.
+#permissions.account.view_activity_log - (Optional) Whether the user can view activity logs.
+#permissions.account.view_invoices - (Optional) Whether the user can view invoices.
+#permissions.account.manage_ip_whitelist - (Optional) Whether the user can manage ip whitelist.
+#permissions.monitoring.manage_lists - (Optional) Whether the user can modify notification lists.
+#permissions.monitoring.manage_jobs - (Optional) Whether the user can create, update, and delete monitoring jobs.
+#permissions.monitoring.create_jobs - (Optional) Whether the user can create monitoring jobs when manage_jobs is not set to true.
+#permissions.monitoring.update_jobs - (Optional) Whether the user can update monitoring jobs when manage_jobs is not set to true.
+#permissions.monitoring.delete_jobs - (Optional) Whether the user can delete monitoring jobs when manage_jobs is not set to true.
+#permissions.monitoring.view_jobs - (Optional) Whether the user can view monitoring jobs.
+#permissions.security.manage_global_2fa - (Optional) Whether the user can manage global two factor authentication.
+#permissions.security.manage_active_directory - (Optional) Whether the user can manage global active directory. Only relevant for the DDI product.
+#permissions.redirects.manage_redirects - (Optional) Whether the user can manage redirects.
+#permissions.insights.view_insights - (Optional) Whether the user can view DNS insights
+#permissions.insights.manage_insights - (Optional) Whether the user can manage DNS insights
+
+
+## Access review
+# Users and API keys that can manage users, including through their teams.
+data "ns1_users" "user_admins" {
+  permission = "account.manage_users"
+}
+
+data "ns1_apikeys" "user_admins" {
+  permission = "account.manage_users"
+}
+
+data "ns1_users" "example_team_members" {
+  team_id = ns1_team.example_team.id
+}
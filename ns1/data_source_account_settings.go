@@ -0,0 +1,71 @@
+package ns1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	billingusage "gopkg.in/ns1/ns1-go.v2/rest/model/billingusage"
+)
+
+func dataSourceAccountSettings() *schema.Resource {
+	s := accountSettingsSchema()
+	for k, v := range s {
+		s[k] = computedSchema(v)
+	}
+	limits := map[string]*schema.Schema{}
+	for _, k := range []string{"queries_limit", "china_queries_limit", "records_limit", "filter_chains_limit", "monitors_limit", "decisions_limit"} {
+		limits[k] = &schema.Schema{Type: schema.TypeInt, Computed: true}
+	}
+	for _, k := range []string{"nxd_protection_enabled", "ddos_protection_enabled"} {
+		limits[k] = &schema.Schema{Type: schema.TypeBool, Computed: true}
+	}
+	s["plan_limits"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     &schema.Resource{Schema: limits},
+	}
+	return &schema.Resource{
+		Schema: s,
+		Read:   dataSourceAccountSettingsRead,
+	}
+}
+
+// dataSourceAccountSettingsRead reads the contact details of the account,
+// and the limits of its plan for the current month.
+func dataSourceAccountSettingsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	s, resp, err := client.Settings.Get()
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	if err := accountSettingsToResourceData(d, s, true); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	limits, resp, err := client.BillingUsage.GetLimits(int32(from.Unix()), int32(now.Unix()))
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	if err := d.Set("plan_limits", planLimitsToSchema(limits)); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting plan_limits for account %d, error: %#v", s.CustomerID, err)
+	}
+	return nil
+}
+
+func planLimitsToSchema(l *billingusage.Limits) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"queries_limit":           int(l.QueriesLimit),
+		"china_queries_limit":     int(l.ChinaQueriesLimit),
+		"records_limit":           int(l.RecordsLimit),
+		"filter_chains_limit":     int(l.FilterChainsLimit),
+		"monitors_limit":          int(l.MonitorsLimit),
+		"decisions_limit":         int(l.DecisionsLimit),
+		"nxd_protection_enabled":  l.NxdProtectionEnabled,
+		"ddos_protection_enabled": l.DdosProtectionEnabled,
+	}}
+}
@@ -0,0 +1,172 @@
+package ns1
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+func dataSourceActivityLog() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"from": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"to": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"resource_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"actor": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntBetween(1, 10000),
+			},
+			"entries": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"actor": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"actor_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"actor_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"timestamp": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Read: activityLogRead,
+	}
+}
+
+// activityLogRead reads the activity log of the account from ns1
+func activityLogRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	from := d.Get("from").(int)
+	to := d.Get("to").(int)
+	resourceType := d.Get("resource_type").(string)
+	actor := d.Get("actor").(string)
+
+	if to != 0 && to <= from {
+		return fmt.Errorf("to (%d) must be greater than from (%d)", to, from)
+	}
+
+	limit := d.Get("limit").(int)
+	params := []ns1.Param{{Key: "limit", Value: strconv.Itoa(limit)}}
+	if from != 0 {
+		params = append(params, ns1.Param{Key: "start", Value: strconv.Itoa(from)})
+	}
+	if to != 0 {
+		params = append(params, ns1.Param{Key: "end", Value: strconv.Itoa(to)})
+	}
+	if resourceType != "" {
+		params = append(params, ns1.Param{Key: "resource_type", Value: resourceType})
+	}
+	activity, resp, err := client.Activity.List(params...)
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	if err := checkActivityPage(len(activity), limit, actor); err != nil {
+		return err
+	}
+
+	if err := d.Set("entries", activityToResourceData(activity, from, to, resourceType, actor)); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting activity log entries, error: %#v", err)
+	}
+
+	d.SetId(fmt.Sprintf("activity-%d-%d-%s-%s", from, to, resourceType, actor))
+	return nil
+}
+
+// checkActivityPage fails when the actor filter would be applied to a full
+// page of entries, as the entries of the actor may be in the entries that
+// were not returned.
+func checkActivityPage(n, limit int, actor string) error {
+	if actor != "" && n >= limit {
+		return fmt.Errorf("the activity log has more than %d entries in the time window, so the entries of %s may be incomplete: narrow from and to, or raise limit", limit, actor)
+	}
+	return nil
+}
+
+// activityToResourceData flattens the activity matching the filters, oldest
+// first. The filters are applied here as well as in the request, so that
+// the entries match them whatever the API does with its parameters. The
+// actor is matched on the name or ID of the user or API key.
+func activityToResourceData(activity []*account.Activity, from, to int, resourceType, actor string) []map[string]interface{} {
+	sorted := make([]*account.Activity, 0, len(activity))
+	for _, a := range activity {
+		if (from != 0 && a.Timestamp < from) || (to != 0 && a.Timestamp > to) {
+			continue
+		}
+		if resourceType != "" && a.ResourceType != resourceType {
+			continue
+		}
+		if actor != "" && a.UserName != actor && a.UserID != actor {
+			continue
+		}
+		sorted = append(sorted, a)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp < sorted[j].Timestamp
+	})
+
+	out := make([]map[string]interface{}, 0, len(sorted))
+	for _, a := range sorted {
+		out = append(out, map[string]interface{}{
+			"id":            a.ID,
+			"actor":         a.UserName,
+			"actor_id":      a.UserID,
+			"actor_type":    a.UserType,
+			"action":        a.Action,
+			"resource_type": a.ResourceType,
+			"resource_id":   a.ResourceID,
+			"timestamp":     a.Timestamp,
+		})
+	}
+	return out
+}
@@ -0,0 +1,83 @@
+package ns1
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+func TestAccDataSourceActivityLog_basic(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	from := time.Now().Add(-time.Hour).Unix()
+	dataSourceName := "data.ns1_activity_log.it"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTeamDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceActivityLog(rString, from),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "resource_type", "team"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "entries.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestActivityToResourceData(t *testing.T) {
+	activity := []*account.Activity{
+		{ID: "3", Timestamp: 300, UserName: "ci", UserID: "k1", Action: "update", ResourceType: "zone", ResourceID: "example.com"},
+		{ID: "1", Timestamp: 100, UserName: "ci", UserID: "k1", Action: "create", ResourceType: "zone", ResourceID: "example.com"},
+		{ID: "2", Timestamp: 200, UserName: "alice", UserID: "alice", Action: "update", ResourceType: "record", ResourceID: "abc"},
+		{ID: "4", Timestamp: 400, UserName: "alice", UserID: "alice", Action: "delete", ResourceType: "zone", ResourceID: "example.org"},
+	}
+
+	out := activityToResourceData(activity, 0, 0, "", "")
+	assert.Len(t, out, 4)
+	assert.Equal(t, "1", out[0]["id"])
+	assert.Equal(t, "4", out[3]["id"])
+
+	out = activityToResourceData(activity, 150, 350, "", "")
+	assert.Len(t, out, 2)
+	assert.Equal(t, "2", out[0]["id"])
+	assert.Equal(t, "3", out[1]["id"])
+
+	out = activityToResourceData(activity, 0, 0, "zone", "alice")
+	assert.Len(t, out, 1)
+	assert.Equal(t, "delete", out[0]["action"])
+	assert.Equal(t, "example.org", out[0]["resource_id"])
+
+	out = activityToResourceData(activity, 0, 0, "", "k1")
+	assert.Len(t, out, 2)
+	assert.Equal(t, "ci", out[0]["actor"])
+}
+
+func TestCheckActivityPage(t *testing.T) {
+	assert.NoError(t, checkActivityPage(1000, 1000, ""))
+	assert.NoError(t, checkActivityPage(999, 1000, "ci"))
+	assert.EqualError(t, checkActivityPage(1000, 1000, "ci"),
+		"the activity log has more than 1000 entries in the time window, so the entries of ci may be incomplete: narrow from and to, or raise limit")
+}
+
+func testAccDataSourceActivityLog(rString string, from int64) string {
+	return fmt.Sprintf(`resource "ns1_team" "t" {
+  name = "terraform acc test team %s"
+}
+
+data "ns1_activity_log" "it" {
+  from          = %d
+  resource_type = "team"
+
+  depends_on = [ns1_team.t]
+}
+`, rString, from)
+}
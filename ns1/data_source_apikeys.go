@@ -0,0 +1,95 @@
+package ns1
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+func dataSourceAPIKeys() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"permission": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: NewStringEnum(permissionNames()).ValidateFunc,
+			},
+			// The key and its secrets are never exported.
+			"apikeys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"teams": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"ip_whitelist": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"ip_whitelist_strict": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"expiry_duration": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_access": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"permissions": computedSchema(permissionsSchema()),
+					},
+				},
+			},
+		},
+		Read: dataSourceAPIKeysRead,
+	}
+}
+
+func dataSourceAPIKeysRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	keys, resp, err := client.APIKeys.List()
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+
+	out := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		if !identityMatches(d, k.TeamIDs, k.Permissions) {
+			continue
+		}
+		out = append(out, map[string]interface{}{
+			"id":                  k.ID,
+			"name":                k.Name,
+			"teams":               k.TeamIDs,
+			"ip_whitelist":        k.IPWhitelist,
+			"ip_whitelist_strict": k.IPWhitelistStrict,
+			"expiry_duration":     k.ExpiryDuration,
+			"last_access":         k.LastAccess,
+			"permissions":         []interface{}{permissionsToSchema(k.Permissions)},
+		})
+	}
+
+	d.SetId(identitiesID(d, "apikeys"))
+	return d.Set("apikeys", out)
+}
@@ -0,0 +1,61 @@
+package ns1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceAPIKeys_basic(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAPIKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAPIKeys(rString),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ns1_apikeys.team", "apikeys.#", "1"),
+					resource.TestCheckResourceAttrPair("data.ns1_apikeys.team", "apikeys.0.id", "ns1_apikey.it", "id"),
+					resource.TestCheckResourceAttr("data.ns1_apikeys.team", "apikeys.0.permissions.0.monitoring.0.view_jobs", "true"),
+					resource.TestCheckNoResourceAttr("data.ns1_apikeys.team", "apikeys.0.key"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceAPIKeysNoKey(t *testing.T) {
+	elem := dataSourceAPIKeys().Schema["apikeys"].Elem.(*schema.Resource)
+	assert.NotContains(t, elem.Schema, "key")
+	assert.NotContains(t, elem.Schema, "secrets")
+}
+
+func testAccDataSourceAPIKeys(rString string) string {
+	return fmt.Sprintf(`resource "ns1_team" "t" {
+  name = "terraform acc test team %s"
+
+  permissions {
+    monitoring {
+      view_jobs = true
+    }
+  }
+}
+
+resource "ns1_apikey" "it" {
+  name  = "terraform acc test key %s"
+  teams = [ns1_team.t.id]
+}
+
+data "ns1_apikeys" "team" {
+  team_id    = ns1_team.t.id
+  depends_on = [ns1_apikey.it]
+}
+`, rString, rString)
+}
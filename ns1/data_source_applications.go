@@ -0,0 +1,92 @@
+package ns1
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/pulsar"
+)
+
+func dataSourceApplications() *schema.Resource {
+	elem := &schema.Resource{Schema: map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}}
+	for k, v := range resourceApplication().Schema {
+		elem.Schema[k] = computedSchema(v)
+	}
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"applications": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     elem,
+			},
+		},
+		Read: dataSourceApplicationsRead,
+	}
+}
+
+func dataSourceApplicationsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	name := d.Get("name").(string)
+
+	apps, resp, err := client.Applications.List()
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+
+	out := make([]interface{}, 0, len(apps))
+	for _, a := range filterApplications(apps, name) {
+		out = append(out, applicationToMap(a))
+	}
+	if err := d.Set("applications", out); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting applications, error: %#v", err)
+	}
+
+	d.SetId(fmt.Sprintf("applications/%s", name))
+	return nil
+}
+
+// filterApplications returns the applications with the name, or all of them
+// when name is empty, sorted by name and ID.
+func filterApplications(apps []*pulsar.Application, name string) []*pulsar.Application {
+	out := make([]*pulsar.Application, 0, len(apps))
+	for _, a := range apps {
+		if name == "" || a.Name == name {
+			out = append(out, a)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// applicationToMap flattens an application with
+// resourceApplicationToResourceData, so that the applications of the data
+// source have the attributes of ns1_application.
+func applicationToMap(a *pulsar.Application) map[string]interface{} {
+	r := resourceApplication()
+	d := r.Data(nil)
+	resourceApplicationToResourceData(d, a)
+
+	m := make(map[string]interface{}, len(r.Schema)+1)
+	for k := range r.Schema {
+		m[k] = d.Get(k)
+	}
+	m["id"] = d.Id()
+	return m
+}
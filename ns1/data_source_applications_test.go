@@ -0,0 +1,74 @@
+package ns1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/pulsar"
+)
+
+func TestAccDataSourceApplications_basic(t *testing.T) {
+	appName := fmt.Sprintf("terraform-test-%s", acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceApplications(appName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ns1_applications.it", "applications.#", "1"),
+					resource.TestCheckResourceAttr("data.ns1_applications.it", "applications.0.name", appName),
+					resource.TestCheckResourceAttr("data.ns1_applications.it", "applications.0.browser_wait_millis", "123"),
+					resource.TestCheckResourceAttrPair("data.ns1_applications.it", "applications.0.id", "ns1_application.it", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestFilterApplications(t *testing.T) {
+	apps := []*pulsar.Application{
+		{ID: "2", Name: "web"},
+		{ID: "1", Name: "web"},
+		{ID: "3", Name: "api"},
+	}
+
+	assert.Equal(t, []*pulsar.Application{apps[2], apps[1], apps[0]}, filterApplications(apps, ""))
+	assert.Equal(t, []*pulsar.Application{apps[1], apps[0]}, filterApplications(apps, "web"))
+	assert.Empty(t, filterApplications(apps, "WEB"))
+}
+
+func TestApplicationToMap(t *testing.T) {
+	m := applicationToMap(&pulsar.Application{
+		ID:                "abc",
+		Name:              "web",
+		Active:            true,
+		BrowserWaitMillis: 100,
+		DefaultConfig:     pulsar.DefaultConfig{HTTP: true, JobTimeoutMillis: 5000},
+	})
+	assert.Equal(t, "abc", m["id"])
+	assert.Equal(t, "web", m["name"])
+	assert.Equal(t, true, m["active"])
+	assert.Equal(t, 100, m["browser_wait_millis"])
+	config := m["default_config"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, true, config["http"])
+	assert.Equal(t, 5000, config["job_timeout_millis"])
+}
+
+func testAccDataSourceApplications(appName string) string {
+	return fmt.Sprintf(`
+resource "ns1_application" "it" {
+  name                = "%s"
+  browser_wait_millis = 123
+}
+
+data "ns1_applications" "it" {
+  name = ns1_application.it.name
+}
+`, appName)
+}
@@ -0,0 +1,76 @@
+package ns1
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+)
+
+func dataSourceDataFeed() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"source_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"config": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+		},
+		Read: dataSourceDataFeedRead,
+	}
+}
+
+// dataSourceDataFeedRead reads a datafeed of a datasource from ns1, by id or by name
+func dataSourceDataFeedRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	sourceID := d.Get("source_id").(string)
+
+	if id, ok := d.GetOk("id"); ok {
+		f, resp, err := client.DataFeeds.Get(sourceID, id.(string))
+		if err != nil {
+			return ConvertToNs1Error(resp, err)
+		}
+		dataFeedToResourceData(d, f)
+		return nil
+	}
+
+	name := d.Get("name").(string)
+	feeds, resp, err := client.DataFeeds.List(sourceID)
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+
+	var found *data.Feed
+	for _, f := range feeds {
+		if f.Name != name {
+			continue
+		}
+		if found != nil {
+			return fmt.Errorf("more than one datafeed named %q in datasource %s, look it up by id instead", name, sourceID)
+		}
+		found = f
+	}
+	if found == nil {
+		return fmt.Errorf("no datafeed named %q in datasource %s", name, sourceID)
+	}
+
+	dataFeedToResourceData(d, found)
+	return nil
+}
@@ -0,0 +1,50 @@
+package ns1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDataFeed_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataFeedDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDataFeed,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ns1_datafeed.by_id", "name", "ns1_datafeed.foobar", "name"),
+					resource.TestCheckResourceAttr("data.ns1_datafeed.by_id", "config.label", "exampledc2"),
+					resource.TestCheckResourceAttrPair("data.ns1_datafeed.by_name", "id", "ns1_datafeed.foobar", "id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceDataFeed = `
+resource "ns1_datasource" "api" {
+  name = "terraform data source test"
+  sourcetype = "nsone_v1"
+}
+
+resource "ns1_datafeed" "foobar" {
+  name = "terraform data source test"
+  source_id = ns1_datasource.api.id
+  config = {
+    label = "exampledc2"
+  }
+}
+
+data "ns1_datafeed" "by_id" {
+  source_id = ns1_datasource.api.id
+  id        = ns1_datafeed.foobar.id
+}
+
+data "ns1_datafeed" "by_name" {
+  source_id = ns1_datasource.api.id
+  name      = ns1_datafeed.foobar.name
+}
+`
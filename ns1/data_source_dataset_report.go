@@ -0,0 +1,277 @@
+package ns1
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dataset"
+)
+
+func dataSourceDatasetReport() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"dataset_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"report_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			// Read-only
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"start": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"end": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"export_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"content": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rows": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+		Read: dataSourceDatasetReportRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+	}
+}
+
+// datasetReportPollInterval is how often a dataset is read while waiting for
+// its report to be generated.
+var datasetReportPollInterval = 10 * time.Second
+
+// findDatasetReport returns the report with the ID. When id is empty, it
+// returns the most recently created available report, or the most recently
+// created report if none is available yet.
+func findDatasetReport(reports []*dataset.Report, id string) *dataset.Report {
+	var found, available *dataset.Report
+	for _, r := range reports {
+		if id != "" {
+			if r.ID == id {
+				return r
+			}
+			continue
+		}
+		if found == nil || time.Time(r.CreatedAt).After(time.Time(found.CreatedAt)) {
+			found = r
+		}
+		if r.Status == dataset.ReportStatusAvailable &&
+			(available == nil || time.Time(r.CreatedAt).After(time.Time(available.CreatedAt))) {
+			available = r
+		}
+	}
+	if available != nil {
+		return available
+	}
+	return found
+}
+
+// datasetReportText reports whether reports of the export type are text,
+// which is downloaded into content and parsed into rows.
+func datasetReportText(exportType dataset.ExportType) bool {
+	return exportType == dataset.ExportTypeCSV || exportType == dataset.ExportTypeJSON
+}
+
+// waitForDatasetReport reads a dataset until its report is available, it
+// fails, or the timeout passes.
+func waitForDatasetReport(client *ns1.Client, datasetID, reportID string, timeout time.Duration) (*dataset.Dataset, *dataset.Report, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		dt, resp, err := client.Datasets.Get(datasetID)
+		if err != nil {
+			if errors.Is(err, ns1.ErrDatasetNotFound) {
+				return nil, nil, fmt.Errorf("dataset %s not found", datasetID)
+			}
+			return nil, nil, ConvertToNs1Error(resp, err)
+		}
+
+		report := findDatasetReport(dt.Reports, reportID)
+		if report == nil && reportID != "" {
+			return nil, nil, fmt.Errorf("dataset %s has no report %s", datasetID, reportID)
+		}
+		if report != nil {
+			switch report.Status {
+			case dataset.ReportStatusAvailable:
+				return dt, report, nil
+			case dataset.ReportStatusFailed:
+				return nil, nil, fmt.Errorf("report %s of dataset %s failed", report.ID, datasetID)
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return nil, nil, fmt.Errorf("timed out after %s waiting for a report of dataset %s to be available", timeout, datasetID)
+		}
+		log.Printf("[DEBUG] waiting for a report of NS1 dataset (%s) to be available", datasetID)
+		time.Sleep(datasetReportPollInterval)
+	}
+}
+
+// parseDatasetReport parses the content of a report into rows keyed by
+// column name. CSV reports are keyed by their header row, JSON reports must
+// be an array of objects, or a single object.
+func parseDatasetReport(exportType dataset.ExportType, content []byte) ([]map[string]string, error) {
+	switch exportType {
+	case dataset.ExportTypeCSV:
+		return parseDatasetReportCSV(content)
+	case dataset.ExportTypeJSON:
+		return parseDatasetReportJSON(content)
+	}
+	return nil, fmt.Errorf("%s reports can't be parsed, use a csv or json export_type", exportType)
+}
+
+func parseDatasetReportCSV(content []byte) ([]map[string]string, error) {
+	r := csv.NewReader(bytes.NewReader(content))
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err == io.EOF {
+		return []map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]map[string]string, 0)
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				row[column] = record[i]
+			} else {
+				row[column] = ""
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseDatasetReportJSON(content []byte) ([]map[string]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	var objects []interface{}
+	switch v := v.(type) {
+	case []interface{}:
+		objects = v
+	case map[string]interface{}:
+		objects = []interface{}{v}
+	default:
+		return nil, errors.New("json report is not an array of objects")
+	}
+
+	rows := make([]map[string]string, 0, len(objects))
+	for _, o := range objects {
+		m, ok := o.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("json report is not an array of objects")
+		}
+		row := make(map[string]string, len(m))
+		for k, v := range m {
+			s, err := datasetReportValue(v)
+			if err != nil {
+				return nil, err
+			}
+			row[k] = s
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// datasetReportValue converts a JSON value to a string. Arrays and objects
+// are kept as JSON.
+func datasetReportValue(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+func dataSourceDatasetReportRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	datasetID := d.Get("dataset_id").(string)
+
+	dt, report, err := waitForDatasetReport(client, datasetID, d.Get("report_id").(string), d.Timeout(schema.TimeoutRead))
+	if err != nil {
+		return err
+	}
+
+	content, rows := "", []map[string]string{}
+	if datasetReportText(dt.ExportType) {
+		buf, resp, err := client.Datasets.GetReport(datasetID, report.ID)
+		if err != nil {
+			return ConvertToNs1Error(resp, err)
+		}
+		rows, err = parseDatasetReport(dt.ExportType, buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("parsing report %s of dataset %s: %s", report.ID, datasetID, err)
+		}
+		content = buf.String()
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", datasetID, report.ID))
+	d.Set("report_id", report.ID)
+	d.Set("status", report.Status)
+	d.Set("start", time.Time(report.Start).Unix())
+	d.Set("end", time.Time(report.End).Unix())
+	d.Set("created_at", time.Time(report.CreatedAt).Unix())
+	d.Set("export_type", dt.ExportType)
+	d.Set("content", content)
+	if err := d.Set("rows", rows); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting rows for %s, error: %#v", d.Id(), err)
+	}
+	return nil
+}
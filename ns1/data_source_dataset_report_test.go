@@ -0,0 +1,111 @@
+package ns1
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dataset"
+)
+
+func TestAccDataSourceDatasetReport_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-test-dataset-%s", acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDatasetReport(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ns1_dataset_report.it", "status", "available"),
+					resource.TestCheckResourceAttr("data.ns1_dataset_report.it", "export_type", "csv"),
+					resource.TestCheckResourceAttrSet("data.ns1_dataset_report.it", "report_id"),
+					resource.TestCheckResourceAttrSet("data.ns1_dataset_report.it", "content"),
+					resource.TestCheckResourceAttrSet("data.ns1_dataset_report.it", "rows.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseDatasetReport(t *testing.T) {
+	rows, err := parseDatasetReport(dataset.ExportTypeCSV, []byte("zone,queries\nexample.com,10\n\"a,b.com\",20\nshort.com\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"zone": "example.com", "queries": "10"},
+		{"zone": "a,b.com", "queries": "20"},
+		{"zone": "short.com", "queries": ""},
+	}, rows)
+
+	rows, err = parseDatasetReport(dataset.ExportTypeCSV, nil)
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+
+	rows, err = parseDatasetReport(dataset.ExportTypeJSON, []byte(`[
+		{"zone": "example.com", "queries": 12345678901, "ratio": 0.5, "billable": true, "note": null, "tags": ["a"]}
+	]`))
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"zone": "example.com", "queries": "12345678901", "ratio": "0.5", "billable": "true", "note": "", "tags": `["a"]`},
+	}, rows)
+
+	rows, err = parseDatasetReport(dataset.ExportTypeJSON, []byte(`{"zone": "example.com"}`))
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{{"zone": "example.com"}}, rows)
+
+	_, err = parseDatasetReport(dataset.ExportTypeJSON, []byte(`[1, 2]`))
+	assert.EqualError(t, err, "json report is not an array of objects")
+	_, err = parseDatasetReport(dataset.ExportTypeXLSX, []byte("PK"))
+	assert.EqualError(t, err, "xlsx reports can't be parsed, use a csv or json export_type")
+
+	assert.True(t, datasetReportText(dataset.ExportTypeCSV))
+	assert.True(t, datasetReportText(dataset.ExportTypeJSON))
+	assert.False(t, datasetReportText(dataset.ExportTypeXLSX))
+}
+
+func TestFindDatasetReport(t *testing.T) {
+	at := func(sec int64) dataset.UnixTimestamp { return dataset.UnixTimestamp(time.Unix(sec, 0)) }
+	reports := []*dataset.Report{
+		{ID: "old", CreatedAt: at(100), Status: dataset.ReportStatusAvailable},
+		{ID: "new", CreatedAt: at(300), Status: dataset.ReportStatusFailed},
+		{ID: "mid", CreatedAt: at(200), Status: dataset.ReportStatusAvailable},
+	}
+
+	assert.Equal(t, "mid", findDatasetReport(reports, "").ID)
+	assert.Equal(t, "new", findDatasetReport(reports, "new").ID)
+	assert.Equal(t, "new", findDatasetReport(reports[1:2], "").ID)
+	assert.Nil(t, findDatasetReport(reports, "missing"))
+	assert.Nil(t, findDatasetReport(nil, ""))
+}
+
+func testAccDataSourceDatasetReport(name string) string {
+	return fmt.Sprintf(`
+resource "ns1_dataset" "it" {
+  name = "%s"
+  datatype {
+    type  = "num_queries"
+    scope = "account"
+    data  = {}
+  }
+  repeat {
+    start         = %d
+    repeats_every = "month"
+    end_after_n   = 1
+  }
+  timeframe {
+    aggregation = "monthly"
+    cycles      = 1
+  }
+  export_type = "csv"
+}
+
+data "ns1_dataset_report" "it" {
+  dataset_id = ns1_dataset.it.id
+}
+`, name, time.Now().Add(time.Minute).Unix())
+}
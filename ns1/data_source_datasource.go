@@ -0,0 +1,80 @@
+package ns1
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+)
+
+func dataSourceDataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"sourcetype": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"config": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"feed_url": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+		Read: dataSourceDataSourceRead,
+	}
+}
+
+// dataSourceDataSourceRead reads a datasource from ns1, by id or by name
+func dataSourceDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+
+	if id, ok := d.GetOk("id"); ok {
+		s, resp, err := client.DataSources.Get(id.(string))
+		if err != nil {
+			return ConvertToNs1Error(resp, err)
+		}
+		dataSourceToResourceData(d, s, client)
+		return nil
+	}
+
+	name := d.Get("name").(string)
+	sources, resp, err := client.DataSources.List()
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+
+	var found *data.Source
+	for _, s := range sources {
+		if s.Name != name {
+			continue
+		}
+		if found != nil {
+			return fmt.Errorf("more than one datasource named %q, look it up by id instead", name)
+		}
+		found = s
+	}
+	if found == nil {
+		return fmt.Errorf("no datasource named %q", name)
+	}
+
+	dataSourceToResourceData(d, found, client)
+	return nil
+}
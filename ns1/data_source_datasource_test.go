@@ -0,0 +1,40 @@
+package ns1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataSourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ns1_datasource.by_id", "name", "ns1_datasource.api", "name"),
+					resource.TestCheckResourceAttr("data.ns1_datasource.by_id", "sourcetype", "nsone_v1"),
+					resource.TestCheckResourceAttrPair("data.ns1_datasource.by_name", "id", "ns1_datasource.api", "id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceDataSource = `
+resource "ns1_datasource" "api" {
+  name = "terraform data source test"
+  sourcetype = "nsone_v1"
+}
+
+data "ns1_datasource" "by_id" {
+  id = ns1_datasource.api.id
+}
+
+data "ns1_datasource" "by_name" {
+  name = ns1_datasource.api.name
+}
+`
@@ -0,0 +1,144 @@
+package ns1
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+func dataSourceMonitoringJob() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"job_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"regions": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"frequency": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"config": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"rapid_recheck": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"mute": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"policy": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"notes": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"notify_delay": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"notify_repeat": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"notify_failback": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"notify_regional": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"notify_list": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rules": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"comparison": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Read: dataSourceMonitoringJobRead,
+	}
+}
+
+// dataSourceMonitoringJobRead reads a monitoring job from ns1, by id or by name
+func dataSourceMonitoringJobRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+
+	if id, ok := d.GetOk("id"); ok {
+		j, resp, err := client.Jobs.Get(id.(string))
+		if err != nil {
+			return ConvertToNs1Error(resp, err)
+		}
+		return monitoringJobToResourceData(d, j)
+	}
+
+	name := d.Get("name").(string)
+	jobs, resp, err := client.Jobs.List()
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+
+	var found *monitor.Job
+	for _, j := range jobs {
+		if j.Name != name {
+			continue
+		}
+		if found != nil {
+			return fmt.Errorf("more than one monitoring job named %q, look it up by id instead", name)
+		}
+		found = j
+	}
+	if found == nil {
+		return fmt.Errorf("no monitoring job named %q", name)
+	}
+
+	return monitoringJobToResourceData(d, found)
+}
@@ -0,0 +1,262 @@
+package ns1
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+// jobMetrics is the response of the monitoring/metrics endpoint, which the
+// SDK does not wrap: RTT and other metrics per region, with a graph of
+// [timestamp, value] pairs.
+type jobMetrics struct {
+	Job     string                                `json:"job"`
+	Metrics map[string]map[string]*jobMetricGraph `json:"metrics"`
+}
+
+type jobMetricGraph struct {
+	Avg   float64      `json:"avg"`
+	Graph [][2]float64 `json:"graph"`
+}
+
+func dataSourceMonitoringJobHistory() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"job_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"from": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"to": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status_log": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"since": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"until": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"metrics": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"metric": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"average": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"points": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"timestamp": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"value": {
+										Type:     schema.TypeFloat,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Read: monitoringJobHistoryRead,
+	}
+}
+
+// monitoringJobHistoryRead reads the status history and metrics of a monitoring job from ns1
+func monitoringJobHistoryRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	jobID := d.Get("job_id").(string)
+	from := d.Get("from").(int)
+	to := d.Get("to").(int)
+	region := d.Get("region").(string)
+
+	if to <= from {
+		return fmt.Errorf("to (%d) must be greater than from (%d)", to, from)
+	}
+	// metrics periods are relative to now, so the period has to reach back to from
+	span := int(time.Now().Unix()) - from
+	if span > metricsMaxSpan {
+		log.Printf("[WARN] NS1 monitoring job (%s): metrics are only kept for the last 30 days, from (%d) is %d days ago",
+			jobID, from, span/(24*3600))
+	}
+	period := metricsPeriod(span)
+
+	logs, resp, err := client.Jobs.History(jobID, func(v *url.Values) {
+		v.Set("start", strconv.Itoa(from))
+		v.Set("end", strconv.Itoa(to))
+		v.Set("exact", "true")
+		if region != "" {
+			v.Set("region", region)
+		}
+	})
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+
+	statusLog := make([]map[string]interface{}, 0, len(logs))
+	for _, l := range logs {
+		if region != "" && l.Region != region {
+			continue
+		}
+		statusLog = append(statusLog, map[string]interface{}{
+			"region": l.Region,
+			"status": l.Status,
+			"since":  l.Since,
+			"until":  l.Until,
+		})
+	}
+	if err := d.Set("status_log", statusLog); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting status log for job %s, error: %#v", jobID, err)
+	}
+
+	metrics, err := getMonitoringJobMetrics(client, jobID, period)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("metrics", metricsToResourceData(metrics, region, from, to)); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting metrics for job %s, error: %#v", jobID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s-%d-%d", jobID, from, to))
+	return nil
+}
+
+// getMonitoringJobMetrics fetches the metrics of a job for the given period.
+func getMonitoringJobMetrics(client *ns1.Client, jobID, period string) ([]*jobMetrics, error) {
+	path := fmt.Sprintf("monitoring/metrics/%s", jobID)
+	req, err := client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var metrics []*jobMetrics
+	resp, err := client.Do(req, &metrics, ns1.Param{Key: "period", Value: period})
+	if err != nil {
+		return nil, ConvertToNs1Error(resp, err)
+	}
+	return metrics, nil
+}
+
+// metricsMaxSpan is how far back NS1 keeps the metrics of monitoring jobs.
+const metricsMaxSpan = 30 * 24 * 3600
+
+// metricsPeriod returns the smallest metrics period covering span seconds.
+// Metrics older than metricsMaxSpan are not kept, so a longer span gets the
+// metrics of the last 30 days.
+func metricsPeriod(span int) string {
+	switch {
+	case span <= 3600:
+		return "1h"
+	case span <= 24*3600:
+		return "24h"
+	case span <= 7*24*3600:
+		return "7d"
+	}
+	return "30d"
+}
+
+// metricsToResourceData flattens the metrics per region, keeping only the
+// points within the from/to window, and averages the points that are kept. Regions and metrics are sorted so the
+// output is stable between reads.
+func metricsToResourceData(metrics []*jobMetrics, region string, from, to int) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0)
+	for _, m := range metrics {
+		regions := make([]string, 0, len(m.Metrics))
+		for r := range m.Metrics {
+			if region == "" || r == region {
+				regions = append(regions, r)
+			}
+		}
+		sort.Strings(regions)
+
+		for _, r := range regions {
+			names := make([]string, 0, len(m.Metrics[r]))
+			for name := range m.Metrics[r] {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				graph := m.Metrics[r][name]
+				if graph == nil {
+					continue
+				}
+				points := make([]map[string]interface{}, 0, len(graph.Graph))
+				var sum float64
+				for _, p := range graph.Graph {
+					ts := int(p[0])
+					if ts < from || ts > to {
+						continue
+					}
+					points = append(points, map[string]interface{}{
+						"timestamp": ts,
+						"value":     p[1],
+					})
+					sum += p[1]
+				}
+				var average float64
+				if len(points) > 0 {
+					average = sum / float64(len(points))
+				}
+				out = append(out, map[string]interface{}{
+					"region":  r,
+					"metric":  name,
+					"average": average,
+					"points":  points,
+				})
+			}
+		}
+	}
+	return out
+}
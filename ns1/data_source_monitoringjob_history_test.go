@@ -0,0 +1,98 @@
+package ns1
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceMonitoringJobHistory_basic(t *testing.T) {
+	from := time.Now().Add(-24 * time.Hour).Unix()
+	to := time.Now().Unix()
+	dataSourceName := "data.ns1_monitoringjob_history.it"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMonitoringJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMonitoringJobHistory(from, to),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "job_id", "ns1_monitoringjob.it", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "from", fmt.Sprintf("%d", from)),
+					resource.TestCheckResourceAttr(dataSourceName, "to", fmt.Sprintf("%d", to)),
+					resource.TestCheckResourceAttrSet(dataSourceName, "status_log.#"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "metrics.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestMetricsPeriod(t *testing.T) {
+	for span, want := range map[int]string{
+		600:            "1h",
+		2 * 3600:       "24h",
+		3 * 24 * 3600:  "7d",
+		30 * 24 * 3600: "30d",
+	} {
+		assert.Equal(t, want, metricsPeriod(span))
+	}
+	assert.Equal(t, "30d", metricsPeriod(31*24*3600))
+}
+
+func TestMetricsToResourceData(t *testing.T) {
+	metrics := []*jobMetrics{
+		{
+			Job: "job",
+			Metrics: map[string]map[string]*jobMetricGraph{
+				"sjc": {"rtt": {Avg: 20, Graph: [][2]float64{{100, 21}, {200, 19}}}},
+				"lga": {"rtt": {Avg: 7, Graph: [][2]float64{{50, 8}, {150, 6}, {250, 7}}}},
+			},
+		},
+	}
+
+	out := metricsToResourceData(metrics, "", 100, 200)
+	assert.Len(t, out, 2)
+	assert.Equal(t, "lga", out[0]["region"])
+	assert.Equal(t, "rtt", out[0]["metric"])
+	assert.Len(t, out[0]["points"], 1)
+	assert.Equal(t, 6.0, out[0]["average"])
+	assert.Equal(t, "sjc", out[1]["region"])
+	assert.Len(t, out[1]["points"], 2)
+
+	out = metricsToResourceData(metrics, "sjc", 0, 1000)
+	assert.Len(t, out, 1)
+	assert.Equal(t, 20.0, out[0]["average"])
+
+	out = metricsToResourceData(metrics, "sjc", 300, 400)
+	assert.Empty(t, out[0]["points"])
+	assert.Equal(t, 0.0, out[0]["average"])
+}
+
+func testAccDataSourceMonitoringJobHistory(from, to int64) string {
+	return fmt.Sprintf(`
+resource "ns1_monitoringjob" "it" {
+  job_type = "tcp"
+  name     = "terraform history test"
+
+  regions   = ["lga","sjc","sin"]
+  frequency = 60
+
+  config = {
+    port = 443
+    host = "1.2.3.4"
+  }
+}
+
+data "ns1_monitoringjob_history" "it" {
+  job_id = ns1_monitoringjob.it.id
+  from   = %d
+  to     = %d
+}
+`, from, to)
+}
@@ -0,0 +1,51 @@
+package ns1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceMonitoringJob_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMonitoringJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMonitoringJob,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ns1_monitoringjob.by_id", "name", "ns1_monitoringjob.it", "name"),
+					resource.TestCheckResourceAttr("data.ns1_monitoringjob.by_id", "job_type", "tcp"),
+					resource.TestCheckResourceAttr("data.ns1_monitoringjob.by_id", "frequency", "60"),
+					resource.TestCheckResourceAttr("data.ns1_monitoringjob.by_id", "config.host", "1.2.3.4"),
+					resource.TestCheckResourceAttrPair("data.ns1_monitoringjob.by_name", "id", "ns1_monitoringjob.it", "id"),
+					resource.TestCheckResourceAttr("data.ns1_monitoringjob.by_name", "regions.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceMonitoringJob = `
+resource "ns1_monitoringjob" "it" {
+  job_type = "tcp"
+  name     = "terraform data source test"
+
+  regions   = ["lga","sjc"]
+  frequency = 60
+
+  config = {
+    port = 443
+    host = "1.2.3.4"
+  }
+}
+
+data "ns1_monitoringjob" "by_id" {
+  id = ns1_monitoringjob.it.id
+}
+
+data "ns1_monitoringjob" "by_name" {
+  name = ns1_monitoringjob.it.name
+}
+`
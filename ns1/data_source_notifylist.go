@@ -0,0 +1,207 @@
+package ns1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+func dataSourceNotifyList() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"email": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"webhook": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"headers": {
+							Type:      schema.TypeMap,
+							Computed:  true,
+							Sensitive: true,
+							Elem:      &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"slack": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"username": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"channel": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"pagerduty": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_key": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+			"datafeed": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"user": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			// notifiers without a typed block
+			"notifications": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"config": {
+							Type:      schema.TypeMap,
+							Computed:  true,
+							Sensitive: true,
+							Elem:      &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+		Read: dataSourceNotifyListRead,
+	}
+}
+
+// dataSourceNotifyListRead reads a notify list from ns1, by id or by name
+func dataSourceNotifyListRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+
+	if id, ok := d.GetOk("id"); ok {
+		nl, resp, err := client.Notifications.Get(id.(string))
+		if err != nil {
+			return ConvertToNs1Error(resp, err)
+		}
+		return notifyListToDataSource(d, nl)
+	}
+
+	name := d.Get("name").(string)
+	lists, resp, err := client.Notifications.List()
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+
+	var found *monitor.NotifyList
+	for _, nl := range lists {
+		if nl.Name != name {
+			continue
+		}
+		if found != nil {
+			return fmt.Errorf("more than one notify list named %q, look it up by id instead", name)
+		}
+		found = nl
+	}
+	if found == nil {
+		return fmt.Errorf("no notify list named %q", name)
+	}
+
+	return notifyListToDataSource(d, found)
+}
+
+// notifyListToDataSource sets the typed notifier blocks, and the notifiers
+// without one in notifications.
+func notifyListToDataSource(d *schema.ResourceData, nl *monitor.NotifyList) error {
+	d.SetId(nl.ID)
+	d.Set("name", nl.Name)
+	if err := notifiersToResourceData(d, nl.Notifications); err != nil {
+		return err
+	}
+
+	untyped := make([]interface{}, 0)
+	for _, n := range nl.Notifications {
+		if containsString(notifierTypes, n.Type) {
+			continue
+		}
+		cfg := make(map[string]interface{}, len(n.Config))
+		for k, v := range n.Config {
+			if str, ok := v.(string); ok {
+				cfg[k] = str
+				continue
+			}
+			b, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			cfg[k] = string(b)
+		}
+		untyped = append(untyped, map[string]interface{}{"type": n.Type, "config": cfg})
+	}
+	if err := d.Set("notifications", untyped); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting notifications, error: %#v", err)
+	}
+	return nil
+}
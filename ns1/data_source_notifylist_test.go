@@ -0,0 +1,70 @@
+package ns1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+func TestAccDataSourceNotifyList_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNotifyListDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceNotifyList,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ns1_notifylist.by_id", "name", "ns1_notifylist.test", "name"),
+					resource.TestCheckResourceAttr("data.ns1_notifylist.by_id", "webhook.#", "1"),
+					resource.TestCheckResourceAttr("data.ns1_notifylist.by_id", "email.#", "1"),
+					resource.TestCheckResourceAttrPair("data.ns1_notifylist.by_name", "id", "ns1_notifylist.test", "id"),
+					resource.TestCheckResourceAttr("data.ns1_notifylist.by_name", "webhook.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestNotifyListToDataSource(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceNotifyList().Schema, map[string]interface{}{"id": "abc"})
+	err := notifyListToDataSource(d, &monitor.NotifyList{
+		ID:   "abc",
+		Name: "list",
+		Notifications: []*monitor.Notification{
+			monitor.NewEmailNotification("jdoe@example.com"),
+			{Type: "opsgenie", Config: monitor.Config{"api_key": "secret", "priority": float64(3)}},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "list", d.Get("name"))
+	assert.Equal(t, 1, d.Get("email.#"))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "opsgenie", "config": map[string]interface{}{"api_key": "secret", "priority": "3"}},
+	}, d.Get("notifications"))
+}
+
+const testAccDataSourceNotifyList = `
+resource "ns1_notifylist" "test" {
+  name = "terraform test data source"
+  webhook {
+    url = "http://localhost:9090"
+  }
+  email {
+    address = "tf-test@example.com"
+  }
+}
+
+data "ns1_notifylist" "by_id" {
+  id = ns1_notifylist.test.id
+}
+
+data "ns1_notifylist" "by_name" {
+  name = ns1_notifylist.test.name
+}
+`
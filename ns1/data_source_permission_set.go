@@ -0,0 +1,77 @@
+package ns1
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+// permissionSets are the presets of ns1_permission_set. Security permissions
+// are left off, unlike the default of the permissions block.
+var permissionSets = map[string]func() account.PermissionsMap{
+	"read-only": func() account.PermissionsMap {
+		p := schemaToPermissions(nil)
+		p.Security = &account.PermissionsSecurity{}
+		p.DNS.ViewZones = true
+		p.DNS.ZonesAllowByDefault = true
+		p.Account.ViewActivityLog = true
+		p.Account.ViewInvoices = true
+		p.Monitoring.ViewJobs = true
+		p.Insights.ViewInsights = true
+		return p
+	},
+	"dns-operator": func() account.PermissionsMap {
+		p := schemaToPermissions(nil)
+		p.Security = &account.PermissionsSecurity{}
+		p.DNS.ViewZones = true
+		p.DNS.ManageZones = true
+		p.DNS.ZonesAllowByDefault = true
+		p.Data.PushToDatafeeds = true
+		p.Data.ManageDatasources = true
+		p.Data.ManageDatafeeds = true
+		p.Monitoring.ViewJobs = true
+		return p
+	},
+	"monitoring-admin": func() account.PermissionsMap {
+		p := schemaToPermissions(nil)
+		p.Security = &account.PermissionsSecurity{}
+		p.DNS.ViewZones = true
+		p.DNS.ZonesAllowByDefault = true
+		p.Data.ManageDatafeeds = true
+		p.Monitoring.ManageLists = true
+		p.Monitoring.ManageJobs = true
+		p.Monitoring.CreateJobs = true
+		p.Monitoring.UpdateJobs = true
+		p.Monitoring.DeleteJobs = true
+		p.Monitoring.ViewJobs = true
+		return p
+	},
+}
+
+func dataSourcePermissionSet() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: NewStringEnum([]string{"read-only", "dns-operator", "monitoring-admin"}).ValidateFunc,
+			},
+			"permissions": computedSchema(permissionsSchema()),
+		},
+		Read: dataSourcePermissionSetRead,
+	}
+}
+
+// dataSourcePermissionSetRead sets the permissions of a preset.
+func dataSourcePermissionSetRead(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+	set, ok := permissionSets[name]
+	if !ok {
+		return fmt.Errorf("unknown permission set %q", name)
+	}
+
+	d.SetId(name)
+	return d.Set("permissions", []interface{}{permissionsToSchema(set())})
+}
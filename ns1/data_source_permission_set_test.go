@@ -0,0 +1,55 @@
+package ns1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourcePermissionSet_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTeamDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePermissionSet,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ns1_permission_set.read_only", "permissions.0.dns.0.view_zones", "true"),
+					resource.TestCheckResourceAttr("data.ns1_permission_set.read_only", "permissions.0.dns.0.manage_zones", "false"),
+					testAccCheckPermissionAttr("ns1_team.auditors", "dns.view_zones", "true"),
+					testAccCheckPermissionAttr("ns1_team.auditors", "security.manage_global_2fa", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestPermissionSets(t *testing.T) {
+	for name, set := range permissionSets {
+		p := set()
+		assert.False(t, p.Security.ManageGlobal2FA, name)
+		assert.False(t, p.Security.ManageActiveDirectory, name)
+		assert.True(t, p.DNS.ZonesAllowByDefault, name)
+		assert.False(t, p.Account.ManageUsers, name)
+		assert.False(t, p.Account.ManageApikeys, name)
+	}
+
+	readOnly := permissionSets["read-only"]()
+	assert.False(t, readOnly.DNS.ManageZones)
+	assert.False(t, readOnly.Data.ManageDatafeeds)
+	assert.False(t, readOnly.Monitoring.ManageJobs)
+	assert.False(t, readOnly.Insights.ManageInsights)
+}
+
+const testAccDataSourcePermissionSet = `
+data "ns1_permission_set" "read_only" {
+  name = "read-only"
+}
+
+resource "ns1_team" "auditors" {
+  name        = "terraform permission set test"
+  permissions = data.ns1_permission_set.read_only.permissions
+}
+`
@@ -0,0 +1,157 @@
+package ns1
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/pulsar"
+)
+
+func dataSourcePulsarJobs() *schema.Resource {
+	elem := &schema.Resource{Schema: make(map[string]*schema.Schema)}
+	for k, v := range pulsarJobResource().Schema {
+		elem.Schema[k] = computedSchema(v)
+	}
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"type_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateTypeId,
+			},
+			"include_community": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"jobs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     elem,
+			},
+			"job_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		Read: dataSourcePulsarJobsRead,
+	}
+}
+
+func dataSourcePulsarJobsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	appID := d.Get("app_id").(string)
+	name := d.Get("name").(string)
+	typeID := d.Get("type_id").(string)
+	community := d.Get("include_community").(bool)
+
+	appIDs := []string{appID}
+	if appID == "" {
+		apps, resp, err := client.Applications.List()
+		if err != nil {
+			return ConvertToNs1Error(resp, err)
+		}
+		appIDs = make([]string, 0, len(apps))
+		for _, a := range apps {
+			appIDs = append(appIDs, a.ID)
+		}
+	}
+
+	var jobs []*pulsar.Job
+	for _, id := range appIDs {
+		appJobs, resp, err := client.PulsarJobs.List(id)
+		if err != nil {
+			if err == ns1.ErrAppMissing {
+				return fmt.Errorf("application %s not found", id)
+			}
+			return ConvertToNs1Error(resp, err)
+		}
+		jobs = append(jobs, appJobs...)
+	}
+	jobs = filterPulsarJobs(jobs, name, typeID, community)
+
+	out := make([]interface{}, 0, len(jobs))
+	for _, j := range jobs {
+		out = append(out, pulsarJobToMap(j))
+	}
+	if err := d.Set("jobs", out); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting jobs, error: %#v", err)
+	}
+	d.Set("job_ids", pulsarJobIDs(jobs))
+
+	d.SetId(fmt.Sprintf("pulsar_jobs/%s/%s/%s/%t", appID, name, typeID, community))
+	return nil
+}
+
+// filterPulsarJobs returns the jobs with the name and type, or all of them
+// when the filters are empty, leaving out community jobs unless community
+// is true. Jobs are sorted with the account's own jobs first, then by name,
+// application and ID.
+func filterPulsarJobs(jobs []*pulsar.Job, name, typeID string, community bool) []*pulsar.Job {
+	out := make([]*pulsar.Job, 0, len(jobs))
+	for _, j := range jobs {
+		if name != "" && j.Name != name {
+			continue
+		}
+		if typeID != "" && j.TypeID != typeID {
+			continue
+		}
+		if j.Community && !community {
+			continue
+		}
+		out = append(out, j)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Community != b.Community {
+			return !a.Community
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.AppID != b.AppID {
+			return a.AppID < b.AppID
+		}
+		return a.JobID < b.JobID
+	})
+	return out
+}
+
+// pulsarJobIDs maps the names of sorted jobs to their IDs. When several
+// jobs have a name, the first one is kept.
+func pulsarJobIDs(jobs []*pulsar.Job) map[string]interface{} {
+	ids := make(map[string]interface{}, len(jobs))
+	for _, j := range jobs {
+		if _, ok := ids[j.Name]; !ok {
+			ids[j.Name] = j.JobID
+		}
+	}
+	return ids
+}
+
+// pulsarJobToMap flattens a job with pulsarJobToResourceData, so that the
+// jobs of the data source have the attributes of ns1_pulsarjob.
+func pulsarJobToMap(j *pulsar.Job) map[string]interface{} {
+	r := pulsarJobResource()
+	d := r.Data(nil)
+	pulsarJobToResourceData(d, j)
+
+	m := make(map[string]interface{}, len(r.Schema))
+	for k := range r.Schema {
+		m[k] = d.Get(k)
+	}
+	m["job_id"] = j.JobID
+	return m
+}
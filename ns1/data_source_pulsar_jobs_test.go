@@ -0,0 +1,108 @@
+package ns1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/pulsar"
+)
+
+func TestAccDataSourcePulsarJobs_basic(t *testing.T) {
+	appName := fmt.Sprintf("terraform-test-%s", acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum))
+	jobName := fmt.Sprintf("terraform-test-%s", acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPulsarJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePulsarJobs(appName, jobName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ns1_pulsar_jobs.it", "jobs.#", "1"),
+					resource.TestCheckResourceAttr("data.ns1_pulsar_jobs.it", "jobs.0.name", jobName),
+					resource.TestCheckResourceAttr("data.ns1_pulsar_jobs.it", "jobs.0.type_id", "latency"),
+					resource.TestCheckResourceAttr("data.ns1_pulsar_jobs.it", "jobs.0.config.0.host", "testAccHost.com"),
+					resource.TestCheckResourceAttrPair("data.ns1_pulsar_jobs.it", "jobs.0.job_id", "ns1_pulsarjob.it", "id"),
+					resource.TestCheckResourceAttrPair("data.ns1_pulsar_jobs.it", "job_ids."+jobName, "ns1_pulsarjob.it", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestFilterPulsarJobs(t *testing.T) {
+	jobs := []*pulsar.Job{
+		{JobID: "1", AppID: "b", Name: "cdn", TypeID: "latency"},
+		{JobID: "2", AppID: "a", Name: "cdn", TypeID: "latency", Community: true},
+		{JobID: "3", AppID: "a", Name: "cdn", TypeID: "custom"},
+		{JobID: "4", AppID: "a", Name: "api", TypeID: "latency"},
+	}
+
+	out := filterPulsarJobs(jobs, "", "", true)
+	assert.Equal(t, []*pulsar.Job{jobs[3], jobs[2], jobs[0], jobs[1]}, out)
+	assert.Equal(t, map[string]interface{}{"api": "4", "cdn": "3"}, pulsarJobIDs(out))
+
+	assert.Equal(t, []*pulsar.Job{jobs[3], jobs[2], jobs[0]}, filterPulsarJobs(jobs, "", "", false))
+	assert.Equal(t, []*pulsar.Job{jobs[0], jobs[1]}, filterPulsarJobs(jobs, "cdn", "latency", true))
+	assert.Equal(t, []*pulsar.Job{jobs[1]}, filterPulsarJobs(jobs[1:2], "", "", true))
+}
+
+func TestPulsarJobToMap(t *testing.T) {
+	host := "example.com"
+	m := pulsarJobToMap(&pulsar.Job{
+		JobID:     "abc",
+		AppID:     "app",
+		Name:      "cdn",
+		TypeID:    "latency",
+		Community: true,
+		Config: &pulsar.JobConfig{
+			Host: &host,
+			BlendMetricWeights: &pulsar.BlendMetricWeights{
+				Timestamp: 100,
+				Weights:   []*pulsar.Weights{{Name: "avg", Weight: 10, DefaultValue: 2.5, Maximize: true}},
+			},
+		},
+	})
+	assert.Equal(t, "abc", m["job_id"])
+	assert.Equal(t, "app", m["app_id"])
+	assert.Equal(t, true, m["community"])
+	assert.Equal(t, "example.com", m["config"].([]interface{})[0].(map[string]interface{})["host"])
+	assert.Equal(t, 100, m["blend_metric_weights"].([]interface{})[0].(map[string]interface{})["timestamp"])
+
+	// The flattened job can be set on the data source
+	r := dataSourcePulsarJobs()
+	d := r.Data(nil)
+	require.NoError(t, d.Set("jobs", []interface{}{m}))
+	weights := d.Get("jobs.0.weights").(*schema.Set).List()
+	require.Len(t, weights, 1)
+	assert.Equal(t, "avg", weights[0].(map[string]interface{})["name"])
+	assert.Equal(t, 2.5, weights[0].(map[string]interface{})["default_value"])
+}
+
+func testAccDataSourcePulsarJobs(appName, jobName string) string {
+	return fmt.Sprintf(`
+resource "ns1_application" "app" {
+  name = "%s"
+}
+
+resource "ns1_pulsarjob" "it" {
+  name    = "%s"
+  type_id = "latency"
+  app_id  = ns1_application.app.id
+  config {
+    host     = "testAccHost.com"
+    url_path = "/testAccURLPath"
+  }
+}
+
+data "ns1_pulsar_jobs" "it" {
+  app_id = ns1_pulsarjob.it.app_id
+}
+`, appName, jobName)
+}
@@ -0,0 +1,205 @@
+package ns1
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+var PulsarPerformanceGroupByStringEnum = NewStringEnum([]string{
+	"country",
+	"asn",
+})
+
+var PulsarPerformanceAggregationStringEnum = NewStringEnum([]string{
+	"avg",
+	"p50",
+	"p75",
+	"p90",
+	"p95",
+	"p99",
+})
+
+// pulsarPerformance is the response of the pulsar/query/performance
+// endpoint, which the SDK does not wrap: the RUM measurements of each job
+// aggregated per country or ASN.
+type pulsarPerformance struct {
+	Data []*pulsarPerformanceData `json:"data"`
+}
+
+type pulsarPerformanceData struct {
+	JobID        string  `json:"job_id"`
+	Geo          string  `json:"geo"`
+	Latency      float64 `json:"latency"`
+	Availability float64 `json:"availability"`
+	Count        int64   `json:"count"`
+}
+
+func dataSourcePulsarPerformance() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"job_ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"from": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"to": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"group_by": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "country",
+				ValidateFunc: PulsarPerformanceGroupByStringEnum.ValidateFunc,
+			},
+			"aggregation": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "avg",
+				ValidateFunc: PulsarPerformanceAggregationStringEnum.ValidateFunc,
+			},
+			"performance": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"job_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"country": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"asn": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"latency": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"availability": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"measurements": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Read: pulsarPerformanceRead,
+	}
+}
+
+// pulsarPerformanceRead reads the aggregated performance of Pulsar jobs from ns1
+func pulsarPerformanceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	from := d.Get("from").(int)
+	to := d.Get("to").(int)
+	groupBy := d.Get("group_by").(string)
+	agg := d.Get("aggregation").(string)
+
+	if to <= from {
+		return fmt.Errorf("to (%d) must be greater than from (%d)", to, from)
+	}
+
+	jobIDs := make([]string, 0)
+	for _, id := range d.Get("job_ids").([]interface{}) {
+		jobIDs = append(jobIDs, id.(string))
+	}
+
+	params := []ns1.Param{
+		{Key: "start", Value: strconv.Itoa(from)},
+		{Key: "end", Value: strconv.Itoa(to)},
+		{Key: "geo", Value: groupBy},
+		{Key: "agg", Value: agg},
+	}
+	if len(jobIDs) > 0 {
+		params = append(params, ns1.Param{Key: "jobs", Value: strings.Join(jobIDs, ",")})
+	}
+	perf, err := getPulsarPerformance(client, params)
+	if err != nil {
+		return err
+	}
+
+	out, err := pulsarPerformanceToResourceData(perf, groupBy)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("performance", out); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting Pulsar performance, error: %#v", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s-%d-%d-%s-%s", strings.Join(jobIDs, ","), from, to, groupBy, agg))
+	return nil
+}
+
+// getPulsarPerformance fetches the aggregated performance of Pulsar jobs.
+func getPulsarPerformance(client *ns1.Client, params []ns1.Param) (*pulsarPerformance, error) {
+	req, err := client.NewRequest("GET", "pulsar/query/performance", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var perf pulsarPerformance
+	resp, err := client.Do(req, &perf, params...)
+	if err != nil {
+		return nil, ConvertToNs1Error(resp, err)
+	}
+	return &perf, nil
+}
+
+// pulsarPerformanceToResourceData flattens the performance, setting country
+// or asn following groupBy. Entries are sorted by job and country or ASN so
+// the output is stable between reads.
+func pulsarPerformanceToResourceData(perf *pulsarPerformance, groupBy string) ([]map[string]interface{}, error) {
+	out := make([]map[string]interface{}, 0, len(perf.Data))
+	for _, p := range perf.Data {
+		m := map[string]interface{}{
+			"job_id":       p.JobID,
+			"country":      "",
+			"asn":          0,
+			"latency":      p.Latency,
+			"availability": p.Availability,
+			"measurements": int(p.Count),
+		}
+		if groupBy == "asn" {
+			asn, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(p.Geo), "AS"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid ASN %q in the performance of job %s", p.Geo, p.JobID)
+			}
+			m["asn"] = asn
+		} else {
+			m["country"] = p.Geo
+		}
+		out = append(out, m)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a["job_id"] != b["job_id"] {
+			return a["job_id"].(string) < b["job_id"].(string)
+		}
+		if a["country"] != b["country"] {
+			return a["country"].(string) < b["country"].(string)
+		}
+		return a["asn"].(int) < b["asn"].(int)
+	})
+	return out, nil
+}
@@ -0,0 +1,93 @@
+package ns1
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccDataSourcePulsarPerformance_basic(t *testing.T) {
+	appName := fmt.Sprintf("terraform-test-%s", acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum))
+	jobName := fmt.Sprintf("terraform-test-%s", acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum))
+	from := time.Now().Add(-24 * time.Hour).Unix()
+	to := time.Now().Unix()
+	dataSourceName := "data.ns1_pulsar_performance.it"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPulsarJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePulsarPerformance(appName, jobName, from, to),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "job_ids.0", "ns1_pulsarjob.it", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "group_by", "asn"),
+					resource.TestCheckResourceAttr(dataSourceName, "aggregation", "p90"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "performance.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestPulsarPerformanceToResourceData(t *testing.T) {
+	perf := &pulsarPerformance{Data: []*pulsarPerformanceData{
+		{JobID: "b", Geo: "US", Latency: 40, Availability: 0.99, Count: 10},
+		{JobID: "a", Geo: "FR", Latency: 20, Availability: 1, Count: 5},
+		{JobID: "a", Geo: "BR", Latency: 80, Availability: 0.9, Count: 3},
+	}}
+
+	out, err := pulsarPerformanceToResourceData(perf, "country")
+	require.NoError(t, err)
+	require.Len(t, out, 3)
+	assert.Equal(t, map[string]interface{}{
+		"job_id": "a", "country": "BR", "asn": 0, "latency": 80.0, "availability": 0.9, "measurements": 3,
+	}, out[0])
+	assert.Equal(t, "FR", out[1]["country"])
+	assert.Equal(t, "b", out[2]["job_id"])
+
+	perf = &pulsarPerformance{Data: []*pulsarPerformanceData{
+		{JobID: "a", Geo: "AS7018"},
+		{JobID: "a", Geo: "3356"},
+	}}
+	out, err = pulsarPerformanceToResourceData(perf, "asn")
+	require.NoError(t, err)
+	assert.Equal(t, 3356, out[0]["asn"])
+	assert.Equal(t, 7018, out[1]["asn"])
+	assert.Equal(t, "", out[1]["country"])
+
+	_, err = pulsarPerformanceToResourceData(&pulsarPerformance{Data: []*pulsarPerformanceData{{JobID: "a", Geo: "US"}}}, "asn")
+	assert.EqualError(t, err, `invalid ASN "US" in the performance of job a`)
+}
+
+func testAccDataSourcePulsarPerformance(appName, jobName string, from, to int64) string {
+	return fmt.Sprintf(`
+resource "ns1_application" "app" {
+  name = "%s"
+}
+
+resource "ns1_pulsarjob" "it" {
+  name    = "%s"
+  type_id = "latency"
+  app_id  = ns1_application.app.id
+  config {
+    host     = "testAccHost.com"
+    url_path = "/testAccURLPath"
+  }
+}
+
+data "ns1_pulsar_performance" "it" {
+  job_ids     = [ns1_pulsarjob.it.id]
+  from        = %d
+  to          = %d
+  group_by    = "asn"
+  aggregation = "p90"
+}
+`, appName, jobName, from, to)
+}
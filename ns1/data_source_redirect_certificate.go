@@ -0,0 +1,73 @@
+package ns1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/redirect"
+)
+
+func dataSourceRedirectCertificate() *schema.Resource {
+	s := make(map[string]*schema.Schema)
+	for k, v := range redirectCertificateResource().Schema {
+		// Arguments that only control how the resource is applied.
+		if k == "wait_for_issuance" || k == "renew_before_days" {
+			continue
+		}
+		s[k] = computedSchema(v)
+	}
+	s["domain"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateFunc:     validateDomain,
+		DiffSuppressFunc: caseSensitivityDiffSuppress,
+	}
+	return &schema.Resource{
+		Schema: s,
+		Read:   dataSourceRedirectCertificateRead,
+	}
+}
+
+func dataSourceRedirectCertificateRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	domain := d.Get("domain").(string)
+
+	certs, resp, err := client.RedirectCertificates.List()
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	cert := findRedirectCert(certs, domain)
+	if cert == nil {
+		return fmt.Errorf("no redirect certificate found for %s", domain)
+	}
+	return redirectCertToResourceData(d, cert)
+}
+
+// findRedirectCert returns the certificate of the domain. When there are
+// several, e.g. while one is revoked, the one that is valid the longest is
+// returned.
+func findRedirectCert(certs []*redirect.Certificate, domain string) *redirect.Certificate {
+	var found *redirect.Certificate
+	for _, c := range certs {
+		if !strings.EqualFold(c.Domain, domain) || c.ID == nil {
+			continue
+		}
+		if c.Errors != nil && *c.Errors == "Revoking" {
+			continue
+		}
+		if found == nil || redirectCertValidUntil(c) > redirectCertValidUntil(found) {
+			found = c
+		}
+	}
+	return found
+}
+
+func redirectCertValidUntil(c *redirect.Certificate) int64 {
+	if c.ValidUntil == nil {
+		return 0
+	}
+	return *c.ValidUntil
+}
@@ -0,0 +1,72 @@
+package ns1
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/redirect"
+)
+
+func TestAccDataSourceRedirectCertificate_basic(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccRedirectPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRedirectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRedirectCertificate(rString),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ns1_redirect_certificate.it", "id", "ns1_redirect_certificate.it", "id"),
+					resource.TestCheckResourceAttrPair("data.ns1_redirect_certificate.it", "valid_until", "ns1_redirect_certificate.it", "valid_until"),
+					resource.TestCheckResourceAttrSet("data.ns1_redirect_certificate.it", "certificate"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`data "ns1_redirect_certificate" "it" {
+  domain = "missing.terraform-test-%s.io"
+}
+`, rString),
+				ExpectError: regexp.MustCompile(`no redirect certificate found`),
+			},
+		},
+	})
+}
+
+func TestFindRedirectCert(t *testing.T) {
+	id := func(s string) *string { return &s }
+	until := func(i int64) *int64 { return &i }
+	revoking := "Revoking"
+	certs := []*redirect.Certificate{
+		{ID: id("old"), Domain: "www.example.com", ValidUntil: until(100)},
+		{ID: id("new"), Domain: "WWW.example.com", ValidUntil: until(200)},
+		{ID: id("revoked"), Domain: "www.example.com", ValidUntil: until(300), Errors: &revoking},
+		{ID: id("wildcard"), Domain: "*.example.com", ValidUntil: until(400)},
+	}
+
+	assert.Equal(t, "new", *findRedirectCert(certs, "www.example.com").ID)
+	assert.Equal(t, "wildcard", *findRedirectCert(certs, "*.example.com").ID)
+	assert.Nil(t, findRedirectCert(certs, "go.example.com"))
+}
+
+func testAccDataSourceRedirectCertificate(rString string) string {
+	return fmt.Sprintf(`
+resource "ns1_zone" "test" {
+  zone = "terraform-test-%s.io"
+}
+
+resource "ns1_redirect_certificate" "it" {
+  domain = "test.${ns1_zone.test.zone}"
+}
+
+data "ns1_redirect_certificate" "it" {
+  domain     = ns1_redirect_certificate.it.domain
+  depends_on = [ns1_redirect_certificate.it]
+}
+`, rString)
+}
@@ -0,0 +1,125 @@
+package ns1
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/redirect"
+)
+
+// redirectDNSRecordFields are the arguments of ns1_redirect that are not
+// attributes of the redirect in NS1.
+var redirectDNSRecordFields = []string{"manage_dns_record", "dns_record_target", "adopt_dns_record", "dns_record_zone"}
+
+func dataSourceRedirects() *schema.Resource {
+	elem := &schema.Resource{Schema: make(map[string]*schema.Schema)}
+	for k, v := range redirectConfigResource().Schema {
+		if containsString(redirectDNSRecordFields, k) {
+			continue
+		}
+		elem.Schema[k] = computedSchema(v)
+	}
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"target_pattern": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"redirects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     elem,
+			},
+		},
+		Read: dataSourceRedirectsRead,
+	}
+}
+
+func dataSourceRedirectsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	domain := d.Get("domain").(string)
+	tag := d.Get("tag").(string)
+	pattern := d.Get("target_pattern").(string)
+
+	cfgs, resp, err := client.Redirects.List()
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	cfgs, err = filterRedirects(cfgs, domain, tag, pattern)
+	if err != nil {
+		return err
+	}
+
+	out := make([]interface{}, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		out = append(out, redirectConfigToMap(cfg))
+	}
+	if err := d.Set("redirects", out); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting redirects, error: %#v", err)
+	}
+
+	d.SetId(fmt.Sprintf("redirects/%s/%s/%s", domain, tag, pattern))
+	return nil
+}
+
+// filterRedirects returns the redirects on the domain, with the tag and a
+// target matching the pattern, sorted by domain and path. Empty filters
+// match every redirect.
+func filterRedirects(cfgs []*redirect.Configuration, domain, tag, pattern string) ([]*redirect.Configuration, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("target_pattern is invalid: %s", err)
+	}
+
+	out := make([]*redirect.Configuration, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		if domain != "" && !strings.EqualFold(cfg.Domain, domain) {
+			continue
+		}
+		if tag != "" && !containsString(cfg.Tags, tag) {
+			continue
+		}
+		if !re.MatchString(cfg.Target) {
+			continue
+		}
+		out = append(out, cfg)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return redirectSetKey(out[i].Domain, out[i].Path) < redirectSetKey(out[j].Domain, out[j].Path)
+	})
+	return out, nil
+}
+
+// redirectConfigToMap flattens a redirect with redirectConfigToResourceData,
+// so that the redirects of the data source have the attributes of
+// ns1_redirect.
+func redirectConfigToMap(cfg *redirect.Configuration) map[string]interface{} {
+	r := redirectConfigResource()
+	d := r.Data(nil)
+	redirectConfigToResourceData(d, cfg)
+
+	m := make(map[string]interface{}, len(r.Schema))
+	for k := range r.Schema {
+		if containsString(redirectDNSRecordFields, k) {
+			continue
+		}
+		m[k] = d.Get(k)
+	}
+	m["id"] = d.Id()
+	return m
+}
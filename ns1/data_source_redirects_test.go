@@ -0,0 +1,140 @@
+package ns1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/redirect"
+)
+
+func TestAccDataSourceRedirects_basic(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	domain := fmt.Sprintf("test.terraform-test-%s.io", rString)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccRedirectPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRedirectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRedirects(rString),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ns1_redirects.domain", "redirects.#", "2"),
+					resource.TestCheckResourceAttr("data.ns1_redirects.domain", "redirects.0.domain", domain),
+					resource.TestCheckResourceAttr("data.ns1_redirects.domain", "redirects.0.path", "/a"),
+					resource.TestCheckResourceAttrPair("data.ns1_redirects.domain", "redirects.0.id", "ns1_redirect.a", "id"),
+					resource.TestCheckResourceAttr("data.ns1_redirects.tagged", "redirects.#", "1"),
+					resource.TestCheckResourceAttrPair("data.ns1_redirects.tagged", "redirects.0.id", "ns1_redirect.b", "id"),
+					resource.TestCheckResourceAttr("data.ns1_redirects.target", "redirects.#", "1"),
+					resource.TestCheckResourceAttrPair("data.ns1_redirects.target", "redirects.0.id", "ns1_redirect.a", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestFilterRedirects(t *testing.T) {
+	cfgs := []*redirect.Configuration{
+		{Domain: "www.example.com", Path: "/b", Target: "https://example.com/b", Tags: []string{"promo"}},
+		{Domain: "WWW.example.com", Path: "/a", Target: "http://example.org/a"},
+		{Domain: "go.example.com", Path: "/c", Target: "https://example.com/c", Tags: []string{"promo"}},
+	}
+
+	out, err := filterRedirects(cfgs, "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, []*redirect.Configuration{cfgs[2], cfgs[1], cfgs[0]}, out)
+
+	out, err = filterRedirects(cfgs, "www.example.com", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, []*redirect.Configuration{cfgs[1], cfgs[0]}, out)
+
+	out, err = filterRedirects(cfgs, "www.example.com", "promo", "")
+	require.NoError(t, err)
+	assert.Equal(t, []*redirect.Configuration{cfgs[0]}, out)
+
+	out, err = filterRedirects(cfgs, "", "", `^http://`)
+	require.NoError(t, err)
+	assert.Equal(t, []*redirect.Configuration{cfgs[1]}, out)
+
+	_, err = filterRedirects(cfgs, "", "", `(`)
+	assert.Error(t, err)
+}
+
+func TestRedirectConfigToMap(t *testing.T) {
+	id, cert := "abc", "def"
+	fwType := redirect.Masking
+	https := true
+	m := redirectConfigToMap(&redirect.Configuration{
+		ID:             &id,
+		CertificateID:  &cert,
+		Domain:         "www.example.com",
+		Path:           "/a",
+		Target:         "https://example.com",
+		Tags:           []string{"promo"},
+		ForwardingType: &fwType,
+		HttpsEnabled:   &https,
+	})
+
+	assert.Equal(t, "abc", m["id"])
+	assert.Equal(t, "def", m["certificate_id"])
+	assert.Equal(t, "www.example.com", m["domain"])
+	assert.Equal(t, "masking", m["forwarding_type"])
+	assert.Equal(t, true, m["https_enabled"])
+	assert.Equal(t, []interface{}{"promo"}, m["tags"].(*schema.Set).List())
+
+	// every attribute of the data source is set
+	elem := dataSourceRedirects().Schema["redirects"].Elem.(*schema.Resource)
+	for k := range elem.Schema {
+		assert.Contains(t, m, k)
+	}
+	assert.Len(t, m, len(elem.Schema))
+
+	// and the DNS record arguments of ns1_redirect are not
+	for _, k := range []string{"manage_dns_record", "dns_record_target", "adopt_dns_record", "dns_record_zone"} {
+		assert.NotContains(t, elem.Schema, k)
+		assert.NotContains(t, m, k)
+	}
+}
+
+func testAccDataSourceRedirects(rString string) string {
+	return fmt.Sprintf(`
+resource "ns1_zone" "test" {
+  zone = "terraform-test-%s.io"
+}
+
+resource "ns1_redirect" "a" {
+  domain = "test.${ns1_zone.test.zone}"
+  path   = "/a"
+  target = "https://url.com/a"
+}
+
+resource "ns1_redirect" "b" {
+  domain = "test.${ns1_zone.test.zone}"
+  path   = "/b"
+  target = "http://url.com/b"
+  tags   = ["audit"]
+}
+
+data "ns1_redirects" "domain" {
+  domain     = "test.${ns1_zone.test.zone}"
+  depends_on = [ns1_redirect.a, ns1_redirect.b]
+}
+
+data "ns1_redirects" "tagged" {
+  domain     = "test.${ns1_zone.test.zone}"
+  tag        = "audit"
+  depends_on = [ns1_redirect.a, ns1_redirect.b]
+}
+
+data "ns1_redirects" "target" {
+  domain         = "test.${ns1_zone.test.zone}"
+  target_pattern = "^https://"
+  depends_on     = [ns1_redirect.a, ns1_redirect.b]
+}
+`, rString)
+}
@@ -0,0 +1,88 @@
+package ns1
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+func dataSourceTeams() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"permission": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: NewStringEnum(permissionNames()).ValidateFunc,
+			},
+			"teams": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ip_whitelist": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"values": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+						"permissions": computedSchema(permissionsSchema()),
+					},
+				},
+			},
+		},
+		Read: dataSourceTeamsRead,
+	}
+}
+
+func dataSourceTeamsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	teams, resp, err := client.Teams.List()
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].ID < teams[j].ID })
+
+	out := make([]interface{}, 0, len(teams))
+	for _, t := range teams {
+		if !identityMatches(d, nil, t.Permissions) {
+			continue
+		}
+		wl := make([]interface{}, 0, len(t.IPWhitelist))
+		for _, l := range t.IPWhitelist {
+			wl = append(wl, map[string]interface{}{
+				"name":   l.Name,
+				"values": l.Values,
+			})
+		}
+		out = append(out, map[string]interface{}{
+			"id":           t.ID,
+			"name":         t.Name,
+			"ip_whitelist": wl,
+			"permissions":  []interface{}{permissionsToSchema(t.Permissions)},
+		})
+	}
+
+	d.SetId(identitiesID(d, "teams"))
+	return d.Set("teams", out)
+}
@@ -0,0 +1,68 @@
+package ns1
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataSourceTeams_basic(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTeamDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceTeams(rString),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTeamsListed("data.ns1_teams.redirects", "ns1_team.t"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckTeamsListed checks that a team is in the teams of a ns1_teams.
+func testAccCheckTeamsListed(n, team string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ds, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		rs, ok := s.RootModule().Resources[team]
+		if !ok {
+			return fmt.Errorf("not found: %s", team)
+		}
+
+		for k, v := range ds.Primary.Attributes {
+			if strings.HasPrefix(k, "teams.") && strings.HasSuffix(k, ".id") && v == rs.Primary.ID {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: team %s not listed", n, rs.Primary.ID)
+	}
+}
+
+func testAccDataSourceTeams(rString string) string {
+	return fmt.Sprintf(`resource "ns1_team" "t" {
+  name = "terraform acc test team %s"
+
+  permissions {
+    redirects {
+      manage_redirects = true
+    }
+  }
+}
+
+data "ns1_teams" "redirects" {
+  permission = "redirects.manage_redirects"
+  depends_on = [ns1_team.t]
+}
+`, rString)
+}
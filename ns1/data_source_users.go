@@ -0,0 +1,125 @@
+package ns1
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+func dataSourceUsers() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"permission": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: NewStringEnum(permissionNames()).ValidateFunc,
+			},
+			"users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"username": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"teams": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"ip_whitelist": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"ip_whitelist_strict": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"two_factor_auth_enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"sso": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"last_access": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"permissions": computedSchema(permissionsSchema()),
+					},
+				},
+			},
+		},
+		Read: dataSourceUsersRead,
+	}
+}
+
+// identityMatches reports whether a user, API key or team passes the team_id
+// and permission filters of a data source.
+func identityMatches(d *schema.ResourceData, teamIDs []string, p account.PermissionsMap) bool {
+	// ns1_teams has no team_id filter.
+	if teamID, _ := d.Get("team_id").(string); teamID != "" && !containsString(teamIDs, teamID) {
+		return false
+	}
+	if name := d.Get("permission").(string); name != "" && !hasPermission(p, name) {
+		return false
+	}
+	return true
+}
+
+// identitiesID returns the id of a list data source, from its filters.
+func identitiesID(d *schema.ResourceData, kind string) string {
+	teamID, _ := d.Get("team_id").(string)
+	return fmt.Sprintf("%s/%s/%s", kind, teamID, d.Get("permission").(string))
+}
+
+func dataSourceUsersRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	users, resp, err := client.Users.List()
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+
+	out := make([]interface{}, 0, len(users))
+	for _, u := range users {
+		if !identityMatches(d, u.TeamIDs, u.Permissions) {
+			continue
+		}
+		out = append(out, map[string]interface{}{
+			"name":                    u.Name,
+			"username":                u.Username,
+			"email":                   u.Email,
+			"teams":                   u.TeamIDs,
+			"ip_whitelist":            u.IPWhitelist,
+			"ip_whitelist_strict":     u.IPWhitelistStrict,
+			"two_factor_auth_enabled": u.TwoFactorAuthEnabled,
+			"sso":                     u.SharedAuth.SAML.SSO,
+			"last_access":             int(u.LastAccess),
+			"permissions":             []interface{}{permissionsToSchema(u.Permissions)},
+		})
+	}
+
+	d.SetId(identitiesID(d, "users"))
+	return d.Set("users", out)
+}
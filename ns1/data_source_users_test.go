@@ -0,0 +1,102 @@
+package ns1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceUsers_basic(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceUsers(rString),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ns1_users.team", "users.#", "1"),
+					resource.TestCheckResourceAttrPair("data.ns1_users.team", "users.0.username", "ns1_user.u", "username"),
+					resource.TestCheckResourceAttr("data.ns1_users.team", "users.0.permissions.0.account.0.manage_account_settings", "true"),
+					resource.TestCheckResourceAttr("data.ns1_users.team_and_permission", "users.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestIdentityMatches(t *testing.T) {
+	p := schemaToPermissions(nil)
+	p.DNS.ManageZones = true
+
+	d := schema.TestResourceDataRaw(t, dataSourceUsers().Schema, map[string]interface{}{})
+	assert.True(t, identityMatches(d, nil, p))
+
+	d = schema.TestResourceDataRaw(t, dataSourceUsers().Schema, map[string]interface{}{
+		"team_id":    "t1",
+		"permission": "dns.manage_zones",
+	})
+	assert.True(t, identityMatches(d, []string{"t0", "t1"}, p))
+	assert.False(t, identityMatches(d, []string{"t0"}, p))
+	assert.False(t, identityMatches(d, []string{"t1"}, schemaToPermissions(nil)))
+
+	// ns1_teams only filters by permission.
+	d = schema.TestResourceDataRaw(t, dataSourceTeams().Schema, map[string]interface{}{
+		"permission": "security.manage_global_2fa",
+	})
+	assert.True(t, identityMatches(d, nil, schemaToPermissions(nil)))
+	assert.Equal(t, "teams//security.manage_global_2fa", identitiesID(d, "teams"))
+}
+
+func TestHasPermission(t *testing.T) {
+	p := schemaToPermissions(nil)
+	p.Monitoring.ViewJobs = true
+
+	assert.True(t, hasPermission(p, "monitoring.view_jobs"))
+	assert.False(t, hasPermission(p, "monitoring.manage_jobs"))
+	assert.False(t, hasPermission(p, "monitoring"))
+	assert.False(t, hasPermission(p, "dns.zones_allow"))
+
+	names := permissionNames()
+	assert.Contains(t, names, "dns.view_zones")
+	assert.Contains(t, names, "insights.manage_insights")
+	// Only boolean permissions can be filtered by.
+	assert.NotContains(t, names, "dns.zones_allow")
+}
+
+func testAccDataSourceUsers(rString string) string {
+	return fmt.Sprintf(`resource "ns1_team" "t" {
+  name = "terraform acc test team %s"
+
+  permissions {
+    account {
+      manage_account_settings = true
+    }
+  }
+}
+
+resource "ns1_user" "u" {
+  name     = "terraform acc test user %s"
+  username = "tf_acc_test_user_%s"
+  email    = "tf_acc_test_ns1@hashicorp.com"
+  teams    = [ns1_team.t.id]
+}
+
+data "ns1_users" "team" {
+  team_id    = ns1_team.t.id
+  depends_on = [ns1_user.u]
+}
+
+data "ns1_users" "team_and_permission" {
+  team_id    = ns1_team.t.id
+  permission = "account.manage_users"
+  depends_on = [ns1_user.u]
+}
+`, rString, rString, rString)
+}
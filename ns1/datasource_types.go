@@ -0,0 +1,321 @@
+package ns1
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// configFieldKind is the JSON type NS1 expects for a data source or data feed
+// config value. Terraform holds config maps as strings, so values are
+// converted on the way in and out.
+type configFieldKind int
+
+const (
+	configKindString configFieldKind = iota
+	configKindInt
+	configKindBool
+)
+
+func (k configFieldKind) String() string {
+	switch k {
+	case configKindInt:
+		return "an integer"
+	case configKindBool:
+		return "a boolean"
+	default:
+		return "a string"
+	}
+}
+
+// schemaType is the Terraform type of a typed config block field.
+func (k configFieldKind) schemaType() schema.ValueType {
+	switch k {
+	case configKindInt:
+		return schema.TypeInt
+	case configKindBool:
+		return schema.TypeBool
+	default:
+		return schema.TypeString
+	}
+}
+
+type configField struct {
+	Kind     configFieldKind
+	Required bool
+}
+
+// sourceType describes a supported data source type, from /data/sourcetypes.
+// A nil Config or FeedConfig means the keys are not known and are not
+// validated.
+type sourceType struct {
+	Config     map[string]configField
+	FeedConfig map[string]configField
+	// Push is set for sources whose feeds are updated by posting to the
+	// source's feed URL, rather than polled by NS1.
+	Push bool
+}
+
+var sourceTypes = map[string]sourceType{
+	"nsone_v1": {
+		Config: map[string]configField{},
+		FeedConfig: map[string]configField{
+			"label": {Kind: configKindString, Required: true},
+		},
+		Push: true,
+	},
+	"nsone_monitoring": {
+		Config: map[string]configField{},
+		FeedConfig: map[string]configField{
+			"jobid": {Kind: configKindString, Required: true},
+		},
+	},
+	"aws": {
+		FeedConfig: map[string]configField{
+			"alarm_name": {Kind: configKindString, Required: true},
+		},
+		Push: true,
+	},
+	"datadog": {
+		FeedConfig: map[string]configField{
+			"test_name":       {Kind: configKindString},
+			"fail_on_warning": {Kind: configKindBool},
+			"fail_on_no_data": {Kind: configKindBool},
+		},
+		Push: true,
+	},
+	"pagerduty": {
+		Push: true,
+	},
+	"thousandeyes": {
+		FeedConfig: map[string]configField{
+			"test_id": {Kind: configKindInt, Required: true},
+		},
+		Push: true,
+	},
+	"webcheck": {
+		FeedConfig: map[string]configField{
+			"check_id": {Kind: configKindInt, Required: true},
+		},
+	},
+}
+
+// validateSourceType warns about source types that are not in sourceTypes,
+// since NS1 may support types the provider does not know about yet.
+func validateSourceType(v interface{}, k string) (ws []string, es []error) {
+	if _, ok := sourceTypes[v.(string)]; !ok {
+		ws = append(ws, fmt.Sprintf("%s %q is not a known source type, its config will not be validated", k, v))
+	}
+	return
+}
+
+// validateTypedConfig checks a config map against the fields of a source type.
+// Unknown keys only produce warnings.
+func validateTypedConfig(fields map[string]configField, config map[string]interface{}) (ws []string, es []error) {
+	if fields == nil {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if _, ok := config[k]; !ok && fields[k].Required {
+			es = append(es, fmt.Errorf("config: %q is required", k))
+		}
+	}
+
+	keys = keys[:0]
+	for k := range config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		f, ok := fields[k]
+		if !ok {
+			ws = append(ws, fmt.Sprintf("config: %q is not a known key", k))
+			continue
+		}
+		if _, err := configValueIn(f.Kind, config[k]); err != nil {
+			es = append(es, fmt.Errorf("config: %q must be %s: %w", k, f.Kind, err))
+		}
+	}
+	return ws, es
+}
+
+// typedConfigIn converts the string values of a Terraform config map to the
+// JSON types NS1 expects. Keys without a known kind are passed as is.
+func typedConfigIn(kinds map[string]configFieldKind, config map[string]interface{}) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, len(config))
+	for k, v := range config {
+		kind, ok := kinds[k]
+		if !ok {
+			out[k] = v
+			continue
+		}
+		typed, err := configValueIn(kind, v)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert %s = %v as %s: %w", k, v, kind, err)
+		}
+		out[k] = typed
+	}
+	return out, nil
+}
+
+// typedConfigOut converts the JSON values returned by NS1 back to strings.
+func typedConfigOut(config map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(config))
+	for k, v := range config {
+		switch t := v.(type) {
+		case float64:
+			out[k] = strconv.FormatFloat(t, 'f', -1, 64)
+		case int:
+			out[k] = strconv.Itoa(t)
+		case bool:
+			out[k] = strconv.FormatBool(t)
+		default:
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func configValueIn(kind configFieldKind, v interface{}) (interface{}, error) {
+	s, ok := v.(string)
+	if !ok {
+		return v, nil
+	}
+	switch kind {
+	case configKindInt:
+		return strconv.Atoi(s)
+	case configKindBool:
+		return strconv.ParseBool(s)
+	default:
+		return s, nil
+	}
+}
+
+// sourceConfigKinds returns the kinds of the config keys of a source type.
+func sourceConfigKinds(sourcetype string) map[string]configFieldKind {
+	kinds := make(map[string]configFieldKind)
+	for k, f := range sourceTypes[sourcetype].Config {
+		kinds[k] = f.Kind
+	}
+	return kinds
+}
+
+// feedConfigKinds returns the kinds of the feed config keys of all source
+// types. Feeds only know the id of their source, so keys are converted
+// without looking the source type up.
+func feedConfigKinds() map[string]configFieldKind {
+	kinds := make(map[string]configFieldKind)
+	for _, st := range sourceTypes {
+		for k, f := range st.FeedConfig {
+			kinds[k] = f.Kind
+		}
+	}
+	return kinds
+}
+
+// checkTypedConfig runs validateTypedConfig from a CustomizeDiff, where
+// warnings can only be logged.
+func checkTypedConfig(fields map[string]configField, config map[string]interface{}) error {
+	ws, es := validateTypedConfig(fields, config)
+	for _, w := range ws {
+		log.Printf("[WARN] %s", w)
+	}
+	if len(es) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+// feedConfigBlockNames returns the source types with known feed config keys,
+// which have a typed feed config block of the same name.
+func feedConfigBlockNames() []string {
+	names := make([]string, 0)
+	for name, st := range sourceTypes {
+		if len(st.FeedConfig) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// feedConfigBlocks returns the typed feed config blocks. They conflict with
+// each other and with the untyped config map.
+func feedConfigBlocks() map[string]*schema.Schema {
+	names := feedConfigBlockNames()
+	blocks := make(map[string]*schema.Schema, len(names))
+	for _, name := range names {
+		fields := make(map[string]*schema.Schema)
+		for k, f := range sourceTypes[name].FeedConfig {
+			fields[k] = &schema.Schema{
+				Type:     f.Kind.schemaType(),
+				Required: f.Required,
+				Optional: !f.Required,
+			}
+		}
+		blocks[name] = &schema.Schema{
+			Type:          schema.TypeList,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: append(subtractStrings(names, []string{name}), "config"),
+			Elem:          &schema.Resource{Schema: fields},
+		}
+	}
+	return blocks
+}
+
+// feedConfigFromBlock returns the feed config of a typed block, leaving out
+// optional strings that are not set.
+func feedConfigFromBlock(fields map[string]configField, block map[string]interface{}) map[string]interface{} {
+	config := make(map[string]interface{}, len(fields))
+	for k, f := range fields {
+		v, ok := block[k]
+		if !ok {
+			continue
+		}
+		if s, isString := v.(string); isString && s == "" && !f.Required {
+			continue
+		}
+		config[k] = v
+	}
+	return config
+}
+
+// feedConfigToBlock converts the feed config returned by NS1 to the values of
+// a typed block. NS1 returns numbers as float64.
+func feedConfigToBlock(fields map[string]configField, config map[string]interface{}) map[string]interface{} {
+	block := make(map[string]interface{}, len(fields))
+	for k, f := range fields {
+		v, ok := config[k]
+		if !ok {
+			continue
+		}
+		if f.Kind == configKindInt {
+			if n, isFloat := v.(float64); isFloat {
+				v = int(n)
+			}
+		}
+		if s, isString := v.(string); isString && f.Kind != configKindString {
+			if typed, err := configValueIn(f.Kind, s); err == nil {
+				v = typed
+			}
+		}
+		block[k] = v
+	}
+	return block
+}
@@ -0,0 +1,100 @@
+package ns1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSourceType(t *testing.T) {
+	ws, es := validateSourceType("nsone_v1", "sourcetype")
+	assert.Empty(t, ws)
+	assert.Empty(t, es)
+
+	ws, es = validateSourceType("not_a_source", "sourcetype")
+	assert.Len(t, ws, 1)
+	assert.Empty(t, es)
+}
+
+func TestValidateTypedConfig(t *testing.T) {
+	fields := sourceTypes["datadog"].FeedConfig
+
+	ws, es := validateTypedConfig(fields, map[string]interface{}{
+		"test_name":       "check",
+		"fail_on_warning": "true",
+	})
+	assert.Empty(t, ws)
+	assert.Empty(t, es)
+
+	ws, es = validateTypedConfig(fields, map[string]interface{}{
+		"fail_on_warning": "sometimes",
+		"label":           "x",
+	})
+	assert.Equal(t, []string{`config: "label" is not a known key`}, ws)
+	if assert.Len(t, es, 1) {
+		assert.Contains(t, es[0].Error(), `"fail_on_warning" must be a boolean`)
+	}
+
+	_, es = validateTypedConfig(sourceTypes["thousandeyes"].FeedConfig, map[string]interface{}{})
+	if assert.Len(t, es, 1) {
+		assert.Equal(t, `config: "test_id" is required`, es[0].Error())
+	}
+
+	// Sources without known keys are not validated.
+	ws, es = validateTypedConfig(sourceTypes["pagerduty"].FeedConfig, map[string]interface{}{"anything": "x"})
+	assert.Empty(t, ws)
+	assert.Empty(t, es)
+}
+
+func TestTypedConfigRoundTrip(t *testing.T) {
+	in := map[string]interface{}{
+		"test_id":         "123",
+		"fail_on_no_data": "false",
+		"label":           "exampledc2",
+	}
+
+	typed, err := typedConfigIn(feedConfigKinds(), in)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"test_id":         123,
+		"fail_on_no_data": false,
+		"label":           "exampledc2",
+	}, typed)
+
+	// The API returns numbers as float64.
+	typed["test_id"] = float64(123)
+	assert.Equal(t, in, typedConfigOut(typed))
+
+	_, err = typedConfigIn(feedConfigKinds(), map[string]interface{}{"check_id": "abc"})
+	assert.Error(t, err)
+}
+
+func TestFeedConfigBlocks(t *testing.T) {
+	assert.Equal(t, []string{"aws", "datadog", "nsone_monitoring", "nsone_v1", "thousandeyes", "webcheck"}, feedConfigBlockNames())
+
+	blocks := feedConfigBlocks()
+	te := blocks["thousandeyes"]
+	assert.ElementsMatch(t, []string{"aws", "datadog", "nsone_monitoring", "nsone_v1", "webcheck", "config"}, te.ConflictsWith)
+	testID := te.Elem.(*schema.Resource).Schema["test_id"]
+	assert.Equal(t, schema.TypeInt, testID.Type)
+	assert.True(t, testID.Required)
+	failOnWarning := blocks["datadog"].Elem.(*schema.Resource).Schema["fail_on_warning"]
+	assert.Equal(t, schema.TypeBool, failOnWarning.Type)
+	assert.True(t, failOnWarning.Optional)
+
+	fields := sourceTypes["datadog"].FeedConfig
+	config := feedConfigFromBlock(fields, map[string]interface{}{
+		"test_name":       "",
+		"fail_on_warning": true,
+		"fail_on_no_data": false,
+	})
+	assert.Equal(t, map[string]interface{}{"fail_on_warning": true, "fail_on_no_data": false}, config)
+
+	// The API returns numbers as float64, and configs set through the
+	// untyped map as strings.
+	assert.Equal(t, map[string]interface{}{"test_id": 123},
+		feedConfigToBlock(sourceTypes["thousandeyes"].FeedConfig, map[string]interface{}{"test_id": float64(123)}))
+	assert.Equal(t, map[string]interface{}{"test_name": "check", "fail_on_warning": true},
+		feedConfigToBlock(fields, map[string]interface{}{"test_name": "check", "fail_on_warning": "true", "other": "x"}))
+}
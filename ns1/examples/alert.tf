@@ -10,21 +10,21 @@ resource "ns1_alert" "example_zone_alert" {
   record_ids = []
 }
 
-resource "ns1_alert_sso" "example_saml_alert" {
+resource "ns1_alert" "example_saml_alert" {
   #required
   name               = "Example Alert"
   type               = "account"
   subtype            = "saml_certificate_expired"
   notification_lists = []
-} 
+}
 
-resource "ns1_alert_redirect" "example_redirect_alert" {
+resource "ns1_alert" "example_redirect_alert" {
   #required
   name               = "Example Alert"
   type               = "redirects"
   subtype            = "certificate_renewal_failed"
   notification_lists = []
-} 
+}
 
 resource "ns1_alert" "example_usage_alert" {
   #required
@@ -34,4 +34,13 @@ resource "ns1_alert" "example_usage_alert" {
   data {
     alert_at_percent = 80
   }
-}
\ No newline at end of file
+}
+
+resource "ns1_alert" "example_monitor_usage_alert" {
+  name    = "Example Monitor Usage Alert"
+  type    = "account"
+  subtype = "monitor_usage"
+  data {
+    alert_at_percent = 90
+  }
+}
@@ -18,8 +18,12 @@ resource "ns1_apikey" "expiring_key" {
   expiry_duration = "30d"
 
   # Configure permissions
-  dns_view_zones  = true
-  dns_manage_zones = true
+  permissions {
+    dns {
+      view_zones   = true
+      manage_zones = true
+    }
+  }
 }
 
 # The secrets metadata can be viewed in the state
@@ -27,3 +31,18 @@ output "expiring_key_secrets" {
   value = ns1_apikey.expiring_key.secrets
   sensitive = true
 }
+
+# Rotate the secret of the key every 90 days, or when it is within 7 days of
+# expiring, keeping the previous secret enabled for a day
+resource "time_rotating" "expiring_key" {
+  rotation_days = 90
+}
+
+resource "ns1_apikey_secret" "expiring_key" {
+  apikey_id        = ns1_apikey.expiring_key.id
+  rotation_trigger = time_rotating.expiring_key.id
+
+  #optional
+  renew_before_days = 7
+  overlap_hours     = 24
+}
@@ -12,3 +12,15 @@ resource "ns1_datafeed" "foobar" {
     label = "exampledc2"
   }
 }
+
+resource "ns1_datafeed_publish" "maintenance" {
+  source_id = ns1_datasource.api.id
+  feed_id   = ns1_datafeed.foobar.id
+
+  data = {
+    up = "false"
+  }
+
+  #optional
+  restore_on_destroy = true
+}
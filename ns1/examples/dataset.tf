@@ -0,0 +1,27 @@
+resource "ns1_dataset" "queries" {
+  name = "monthly queries"
+  datatype {
+    type  = "num_queries"
+    scope = "account"
+    data  = {}
+  }
+  repeat {
+    start         = 1735689600
+    repeats_every = "month"
+    end_after_n   = 12
+  }
+  timeframe {
+    aggregation = "monthly"
+    cycles      = 1
+  }
+  export_type = "csv"
+}
+
+# Waits for the latest report of the dataset and parses it
+data "ns1_dataset_report" "queries" {
+  dataset_id = ns1_dataset.queries.id
+}
+
+output "queries" {
+  value = data.ns1_dataset_report.queries.rows
+}
@@ -3,10 +3,23 @@ resource "ns1_notifylist" "test" {
   name = "terraform test"
 
   #optional
-  notifications {
-    type = "webhook"
-    config = {
-      url = "http://localhost:9090"
-    }
+  webhook {
+    url = "http://localhost:9090"
+  }
+}
+
+resource "ns1_alert" "test" {
+  name               = "terraform test alert"
+  type               = "zone"
+  subtype            = "transfer_failed"
+  notification_lists = [ns1_notifylist.test.id]
+}
+
+resource "ns1_notifylist_test" "test" {
+  notify_list_id = ns1_notifylist.test.id
+  alert_id       = ns1_alert.test.id
+
+  trigger = {
+    notifylist = sha1(jsonencode(ns1_notifylist.test))
   }
 }
@@ -2,7 +2,8 @@
 
 # this exists to document those resources and the available permissions
 
-# all permissions on these types are optional
+# all permissions on these types are optional, and are set in a single
+# `permissions` block with one sub-block per section
 
 # resources that support permissions:
 #   ns1_apikey
@@ -10,24 +11,50 @@
 #   ns1_user
 
 # permissions values and types:
-#   dns_view_zones: boolean - allows the requestor to view zones
-#   dns_manage_zones: boolean - allows the requestor to edit/manage zones
-#   dns_zones_allow_by_default: boolean
-#   dns_zones_deny: list of strings - explicitly deny these zones for this user/team/key
-#   dns_zones_allow: list of strings - explicitly allow these zones for this user/team/key
-#   data_push_to_datafeeds: boolean - allows the requestor to push to datafeeds
-#   data_manage_datasources: boolean - allows the requestor to manage datasources
-#   data_manage_datafeeds: boolean - allows the requestor to manage datafeeds
-#   account_manage_users: boolean - allows the requstor to manage users
-#   account_manage_payment_methods: boolean - allows the requestor to manage payment methods
-#   account_manage_teams: boolean - allows the requestor to manage teams
-#   account_manage_apikeys: boolean - allows the requestor to manage apikeys
-#   account_manage_account_settings: boolean - allows the requestor to manage account settings
-#   account_view_activity_log: boolean - allows the requestor to view the activity log
-#   account_view_invoices: boolean - allows the requestor to view account invoices
-#   monitoring_manage_lists: boolean - allows the requestor to manage monitoring lists
-#   monitoring_manage_jobs: boolean - allows the requestor to manage monitoring jobs
-#   monitoring_view_jobs: boolean - allows the requestor to view monitoring jobs
-#   redirects_manage_redirects: boolean - allows the requestor to manage redirects
-#   insights_view_insights: boolean - allows the requestor to view DNS insights
-#   insights_manage_insights: boolean - allows the requestor to manage DNS insights
+#   dns:
+#     view_zones: boolean - allows the requestor to view zones
+#     manage_zones: boolean - allows the requestor to edit/manage zones
+#     zones_allow_by_default: boolean
+#     zones_deny: list of strings - explicitly deny these zones for this user/team/key
+#     zones_allow: list of strings - explicitly allow these zones for this user/team/key
+#     records_allow: blocks of domain, include_subdomains, zone and type - explicitly allow these records
+#     records_deny: blocks of domain, include_subdomains, zone and type - explicitly deny these records
+#   data:
+#     push_to_datafeeds: boolean - allows the requestor to push to datafeeds
+#     manage_datasources: boolean - allows the requestor to manage datasources
+#     manage_datafeeds: boolean - allows the requestor to manage datafeeds
+#   account:
+#     manage_users: boolean - allows the requstor to manage users
+#     manage_payment_methods: boolean - allows the requestor to manage payment methods
+#     manage_teams: boolean - allows the requestor to manage teams
+#     manage_apikeys: boolean - allows the requestor to manage apikeys
+#     manage_account_settings: boolean - allows the requestor to manage account settings
+#     view_activity_log: boolean - allows the requestor to view the activity log
+#     view_invoices: boolean - allows the requestor to view account invoices
+#     manage_ip_whitelist: boolean - allows the requestor to manage the IP whitelist
+#   monitoring:
+#     manage_lists: boolean - allows the requestor to manage monitoring lists
+#     manage_jobs: boolean - allows the requestor to manage monitoring jobs
+#     create_jobs: boolean - allows the requestor to create monitoring jobs
+#     update_jobs: boolean - allows the requestor to update monitoring jobs
+#     delete_jobs: boolean - allows the requestor to delete monitoring jobs
+#     view_jobs: boolean - allows the requestor to view monitoring jobs
+#   security (both default to true):
+#     manage_global_2fa: boolean - allows the requestor to manage global two-factor authentication
+#     manage_active_directory: boolean - allows the requestor to manage active directory
+#   redirects:
+#     manage_redirects: boolean - allows the requestor to manage redirects
+#   insights:
+#     view_insights: boolean - allows the requestor to view DNS insights
+#     manage_insights: boolean - allows the requestor to manage DNS insights
+
+# presets can be looked up with the ns1_permission_set data source, and
+# assigned to the permissions of a user, team or API key
+data "ns1_permission_set" "read_only" {
+  name = "read-only"
+}
+
+resource "ns1_team" "auditors" {
+  name        = "auditors"
+  permissions = data.ns1_permission_set.read_only.permissions
+}
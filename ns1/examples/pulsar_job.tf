@@ -40,3 +40,19 @@ resource "ns1_pulsarjob" "example" {
       maximize = false
   }
 }
+
+# Look up job IDs by name, including community jobs
+data "ns1_pulsar_jobs" "latency" {
+  type_id = "latency"
+}
+
+output "latency_job_ids" {
+  value = data.ns1_pulsar_jobs.latency.job_ids
+}
+
+# Get the performance of the jobs over the last day
+data "ns1_pulsar_performance" "latency" {
+  job_ids = values(data.ns1_pulsar_jobs.latency.job_ids)
+  from    = 1735603200
+  to      = 1735689600
+}
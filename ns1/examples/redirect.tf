@@ -1,6 +1,9 @@
 
 resource "ns1_redirect_certificate" "example" {
   domain       = "*.example.com"
+
+  #optional
+  renew_before_days = 30
 }
 
 resource "ns1_redirect" "example" {
@@ -15,3 +18,29 @@ resource "ns1_redirect" "example" {
   query_forwarding = true
   tags             = []
 }
+
+resource "ns1_redirect" "managed_record" {
+  domain            = "go.example.com"
+  path              = "/*"
+  target            = "https://www.example.com"
+  manage_dns_record = true
+  dns_record_target = "redirect.example.net"
+}
+
+resource "ns1_redirect_set" "example" {
+  domains = ["go.example.com"]
+
+  csv = <<-EOT
+    domain,path,target,forwarding_type
+    go.example.com,/spring-sale,https://www.example.com/offers/spring,permanent
+    go.example.com,/jobs,https://careers.example.com,temporary
+  EOT
+}
+
+data "ns1_redirect_certificate" "central" {
+  domain = "*.example.com"
+}
+
+data "ns1_redirects" "insecure" {
+  target_pattern = "^http://"
+}
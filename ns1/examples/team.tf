@@ -1,29 +1,43 @@
 resource "ns1_team" "foobar" {
   name = "terraform test"
 
-  dns_view_zones             = true
-  dns_zones_allow_by_default = true
-  dns_zones_allow            = ["mytest.zone"]
-  dns_zones_deny             = ["myother.zone"]
+  #optional, checks that the zones of the records ACLs exist
+  verify_records_acl = true
 
-  dns_records_allow {
-      domain = "a.example.com"
-      include_subdomains = false
-      zone = "example.com"
-      type = "A"
-  }
-  dns_records_allow {
-      domain = "my.ns1.com"
-      include_subdomains = true
-      zone = "ns1.com"
-      type = "A"
-  }
-  dns_records_deny {
-      domain = "evil-user.com"
-      include_subdomains = false
-      zone = "evil-user.com"
-      type = "A"
+  permissions {
+    dns {
+      view_zones             = true
+      zones_allow_by_default = true
+      zones_allow            = ["mytest.zone"]
+      zones_deny             = ["myother.zone"]
+      records_allow {
+        domain             = "a.example.com"
+        include_subdomains = false
+        zone               = "example.com"
+        type               = "A"
+      }
+      records_allow {
+        domain             = "my.ns1.com"
+        include_subdomains = true
+        zone               = "ns1.com"
+        type               = "A"
+      }
+      records_deny {
+        domain             = "evil-user.com"
+        include_subdomains = false
+        zone               = "evil-user.com"
+        type               = "A"
+      }
+    }
+
+    data {
+      manage_datasources = true
+    }
   }
+}
 
-  data_manage_datasources = true
+# members can be managed on the team instead of on the users and API keys
+resource "ns1_team_membership" "foobar" {
+  team_id = ns1_team.foobar.id
+  user    = "terraform-test-user"
 }
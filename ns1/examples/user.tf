@@ -13,4 +13,9 @@ resource "ns1_user" "u" {
   notify = {
     billing = true
   }
+  saml {
+    sso          = true
+    provider     = "okta"
+    metadata_url = "https://idp.example.com/metadata"
+  }
 }
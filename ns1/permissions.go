@@ -1,16 +1,38 @@
 package ns1
 
 import (
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
 )
 
 func addPermsSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["permissions"] = permissionsSchema()
+	return s
+}
+
+// addMemberPermsSchema adds the permissions of a user or API key, which can
+// also be a member of teams.
+func addMemberPermsSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s = addPermsSchema(s)
+	s["effective_permissions"] = computedSchema(permissionsSchema())
+	return s
+}
+
+// permissionsSchema is the permissions block of users, API keys and teams.
+// It is an attribute as well as a block, so that it can be assigned from an
+// ns1_permission_set.
+func permissionsSchema() *schema.Schema {
 	dnsRecords := &schema.Schema{
-		Type:             schema.TypeList,
-		Optional:         true,
-		Required:         false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:       schema.TypeList,
+		Optional:   true,
+		ConfigMode: schema.SchemaConfigModeAttr,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"domain": {
@@ -26,376 +48,546 @@ func addPermsSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
 					Required: true,
 				},
 				"type": {
-					Type:     schema.TypeString,
-					Required: true,
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: recordTypeStringEnum.ValidateFunc,
 				},
 			},
 		},
 	}
-	s["dns_records_allow"] = dnsRecords
-	s["dns_records_deny"] = dnsRecords
-	s["dns_view_zones"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["dns_manage_zones"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["dns_zones_allow_by_default"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["dns_zones_deny"] = &schema.Schema{
-		Type:             schema.TypeList,
-		Optional:         true,
-		Elem:             &schema.Schema{Type: schema.TypeString},
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["dns_zones_allow"] = &schema.Schema{
-		Type:             schema.TypeList,
-		Optional:         true,
-		Elem:             &schema.Schema{Type: schema.TypeString},
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["data_push_to_datafeeds"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["data_manage_datasources"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["data_manage_datafeeds"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["account_manage_users"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["account_manage_payment_methods"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["account_manage_plan"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-		Deprecated:       "obsolete, should no longer be used",
-	}
-	s["account_manage_teams"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["account_manage_apikeys"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["account_manage_account_settings"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["account_view_activity_log"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["account_view_invoices"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["account_manage_ip_whitelist"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["monitoring_manage_lists"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["monitoring_manage_jobs"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["monitoring_create_jobs"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["monitoring_update_jobs"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["monitoring_delete_jobs"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["monitoring_view_jobs"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["security_manage_global_2fa"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          true,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["security_manage_active_directory"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          true,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["redirects_manage_redirects"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["insights_view_insights"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
-	}
-	s["insights_manage_insights"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+	zones := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
 	}
-	return s
-}
 
-// If a user or API key is part of a team then this suppresses the diff on the permissions,
-// since it will inherit the permissions of the teams it is on.
-func suppressPermissionDiff(k, old, new string, d *schema.ResourceData) bool {
-	// Don't want to suppress the diff if the key has no value -- e.g. the first time this is ran
-	// otherwise Terraform complains about nil values.
-	if old == "" {
-		return false
+	return &schema.Schema{
+		Type:       schema.TypeList,
+		Optional:   true,
+		MaxItems:   1,
+		ConfigMode: schema.SchemaConfigModeAttr,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"dns": permissionSection(map[string]*schema.Schema{
+					"view_zones":             permissionBool(false),
+					"manage_zones":           permissionBool(false),
+					"zones_allow_by_default": permissionBool(false),
+					"zones_allow":            zones,
+					"zones_deny":             zones,
+					"records_allow":          dnsRecords,
+					"records_deny":           dnsRecords,
+				}),
+				"data": permissionSection(map[string]*schema.Schema{
+					"push_to_datafeeds":  permissionBool(false),
+					"manage_datasources": permissionBool(false),
+					"manage_datafeeds":   permissionBool(false),
+				}),
+				"account": permissionSection(map[string]*schema.Schema{
+					"manage_users":           permissionBool(false),
+					"manage_payment_methods": permissionBool(false),
+					"manage_plan": {
+						Type:       schema.TypeBool,
+						Optional:   true,
+						Default:    false,
+						Deprecated: "obsolete, should no longer be used",
+					},
+					"manage_teams":            permissionBool(false),
+					"manage_apikeys":          permissionBool(false),
+					"manage_account_settings": permissionBool(false),
+					"view_activity_log":       permissionBool(false),
+					"view_invoices":           permissionBool(false),
+					"manage_ip_whitelist":     permissionBool(false),
+				}),
+				"monitoring": permissionSection(map[string]*schema.Schema{
+					"manage_lists": permissionBool(false),
+					"manage_jobs":  permissionBool(false),
+					"create_jobs":  permissionBool(false),
+					"update_jobs":  permissionBool(false),
+					"delete_jobs":  permissionBool(false),
+					"view_jobs":    permissionBool(false),
+				}),
+				"security": permissionSection(map[string]*schema.Schema{
+					"manage_global_2fa":       permissionBool(true),
+					"manage_active_directory": permissionBool(true),
+				}),
+				"redirects": permissionSection(map[string]*schema.Schema{
+					"manage_redirects": permissionBool(false),
+				}),
+				"insights": permissionSection(map[string]*schema.Schema{
+					"view_insights":   permissionBool(false),
+					"manage_insights": permissionBool(false),
+				}),
+			},
+		},
 	}
+}
 
-	oldTeams, newTeams := d.GetChange("teams")
+func permissionSection(s map[string]*schema.Schema) *schema.Schema {
+	return &schema.Schema{
+		Type:       schema.TypeList,
+		Optional:   true,
+		MaxItems:   1,
+		ConfigMode: schema.SchemaConfigModeAttr,
+		Elem:       &schema.Resource{Schema: s},
+	}
+}
 
-	// Check for both old and new team values - if either of them is set,
-	// (e.g. if a user is either being added to a team or removed from one),
-	// then ignore diffs on the permission keys.
-	if teamsRaw, ok := oldTeams.([]interface{}); ok {
-		if len(teamsRaw) > 0 {
-			return true
-		}
+func permissionBool(def bool) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  def,
 	}
+}
 
-	// For some reason, removing a user from a team by completely
-	// deleting the teams block from the config will not show up here,
-	// the old value will still be in newTeams, so there is no way to know
-	// that a user isn't part of any teams anymore. So `terraform apply`
-	// has to be ran again to update the users permissions.
-	if teamsRaw, ok := newTeams.([]interface{}); ok {
-		if len(teamsRaw) > 0 {
-			return true
+// computedSchema returns a computed copy of s, for exporting the same
+// structure from a data source.
+func computedSchema(s *schema.Schema) *schema.Schema {
+	c := &schema.Schema{
+		Type:      s.Type,
+		Computed:  true,
+		Sensitive: s.Sensitive,
+	}
+	switch elem := s.Elem.(type) {
+	case *schema.Schema:
+		c.Elem = &schema.Schema{Type: elem.Type}
+	case *schema.Resource:
+		r := &schema.Resource{Schema: make(map[string]*schema.Schema, len(elem.Schema))}
+		for k, v := range elem.Schema {
+			r.Schema[k] = computedSchema(v)
 		}
+		c.Elem = r
 	}
-
-	return false
+	return c
 }
 
+// permissionsToResourceData sets the permissions block. Sections left at
+// their defaults are omitted, unless they are already in the state, so that
+// only the sections that are configured are diffed.
 func permissionsToResourceData(d *schema.ResourceData, permissions account.PermissionsMap) {
-	d.Set("dns_view_zones", permissions.DNS.ViewZones)
-	d.Set("dns_manage_zones", permissions.DNS.ManageZones)
-	d.Set("dns_zones_allow_by_default", permissions.DNS.ZonesAllowByDefault)
-	d.Set("dns_zones_deny", permissions.DNS.ZonesDeny)
-	d.Set("dns_zones_allow", permissions.DNS.ZonesAllow)
-	d.Set("data_push_to_datafeeds", permissions.Data.PushToDatafeeds)
-	d.Set("data_manage_datasources", permissions.Data.ManageDatasources)
-	d.Set("data_manage_datafeeds", permissions.Data.ManageDatafeeds)
-	d.Set("account_manage_users", permissions.Account.ManageUsers)
-	d.Set("account_manage_payment_methods", permissions.Account.ManagePaymentMethods)
-	d.Set("account_manage_plan", permissions.Account.ManagePlan)
-	d.Set("account_manage_teams", permissions.Account.ManageTeams)
-	d.Set("account_manage_apikeys", permissions.Account.ManageApikeys)
-	d.Set("account_manage_account_settings", permissions.Account.ManageAccountSettings)
-	d.Set("account_view_activity_log", permissions.Account.ViewActivityLog)
-	d.Set("account_view_invoices", permissions.Account.ViewInvoices)
-	d.Set("account_manage_ip_whitelist", permissions.Account.ManageIPWhitelist)
-	d.Set("monitoring_manage_lists", permissions.Monitoring.ManageLists)
-	d.Set("monitoring_manage_jobs", permissions.Monitoring.ManageJobs)
-	d.Set("monitoring_create_jobs", permissions.Monitoring.CreateJobs)
-	d.Set("monitoring_update_jobs", permissions.Monitoring.UpdateJobs)
-	d.Set("monitoring_delete_jobs", permissions.Monitoring.DeleteJobs)
-	d.Set("monitoring_view_jobs", permissions.Monitoring.ViewJobs)
-	d.Set("redirects_manage_redirects", permissions.Redirects.ManageRedirects)
-	d.Set("insights_view_insights", permissions.Insights.ViewInsights)
-	d.Set("insights_manage_insights", permissions.Insights.ManageInsights)
-	if permissions.Security != nil {
-		d.Set("security_manage_global_2fa", permissions.Security.ManageGlobal2FA)
-		d.Set("security_manage_active_directory", permissions.Security.ManageActiveDirectory)
-	}
-	if permissions.DNS.RecordsAllow != nil {
-		d.Set("dns_records_allow", dnsRecordsACLtoSchema(permissions.DNS.RecordsAllow))
-	}
-	if permissions.DNS.RecordsDeny != nil {
-		d.Set("dns_records_deny", dnsRecordsACLtoSchema(permissions.DNS.RecordsDeny))
+	sections := permissionsToSchema(permissions)
+	out := make(map[string]interface{})
+	for name, section := range sections {
+		list := section.([]interface{})
+		if !isDefaultPermissionSection(name, list[0]) || d.Get("permissions.0."+name+".#").(int) > 0 {
+			out[name] = list
+		}
 	}
-}
-
-func resourceDataToPermissions(d *schema.ResourceData) account.PermissionsMap {
-	var p account.PermissionsMap
 
-	if v, ok := d.GetOk("dns_records_allow"); ok {
-		p.DNS.RecordsAllow = schemaToDNSRecordsACL(v)
-	} else {
-		p.DNS.RecordsAllow = []account.PermissionsRecord{}
-	}
-	if v, ok := d.GetOk("dns_records_deny"); ok {
-		p.DNS.RecordsDeny = schemaToDNSRecordsACL(v)
-	} else {
-		p.DNS.RecordsDeny = []account.PermissionsRecord{}
-	}
-	if v, ok := d.GetOk("dns_view_zones"); ok {
-		p.DNS.ViewZones = v.(bool)
-	}
-	if v, ok := d.GetOk("dns_manage_zones"); ok {
-		p.DNS.ManageZones = v.(bool)
+	if len(out) == 0 && d.Get("permissions.#").(int) == 0 {
+		d.Set("permissions", nil)
+		return
 	}
-	if v, ok := d.GetOk("dns_zones_allow_by_default"); ok {
-		p.DNS.ZonesAllowByDefault = v.(bool)
+	d.Set("permissions", []interface{}{out})
+}
+
+// memberPermissionsToResourceData sets the permissions of a user or API key,
+// and its effective permissions, the union of the permissions of its teams
+// and its own. NS1 reports the permissions of a member of teams merged with
+// those of its teams, so its own grants are the reported grants that no team
+// gives, and the configured grants that are reported and a team also gives.
+func memberPermissionsToResourceData(d *schema.ResourceData, client *ns1.Client, teamIDs []string, permissions account.PermissionsMap) error {
+	if len(teamIDs) == 0 {
+		permissionsToResourceData(d, permissions)
+		return d.Set("effective_permissions", []interface{}{permissionsToSchema(permissions)})
 	}
-	if v, ok := d.GetOk("dns_zones_deny"); ok {
-		denyRaw := v.([]interface{})
-		p.DNS.ZonesDeny = make([]string, len(denyRaw))
-		for i, deny := range denyRaw {
-			p.DNS.ZonesDeny[i] = deny.(string)
+
+	var teams *account.PermissionsMap
+	for _, id := range teamIDs {
+		t, resp, err := client.Teams.Get(id)
+		if err != nil {
+			if err == ns1.ErrTeamMissing {
+				log.Printf("[DEBUG] NS1 team (%s) not found", id)
+				continue
+			}
+			return ConvertToNs1Error(resp, err)
 		}
-	} else {
-		p.DNS.ZonesDeny = make([]string, 0)
-	}
-	if v, ok := d.GetOk("dns_zones_allow"); ok {
-		allowRaw := v.([]interface{})
-		p.DNS.ZonesAllow = make([]string, len(allowRaw))
-		for i, allow := range allowRaw {
-			p.DNS.ZonesAllow[i] = allow.(string)
+		if teams == nil {
+			teams = &t.Permissions
+			continue
 		}
-	} else {
-		p.DNS.ZonesAllow = make([]string, 0)
-	}
-	if v, ok := d.GetOk("data_push_to_datafeeds"); ok {
-		p.Data.PushToDatafeeds = v.(bool)
+		union := unionPermissions(*teams, t.Permissions)
+		teams = &union
 	}
-	if v, ok := d.GetOk("data_manage_datasources"); ok {
-		p.Data.ManageDatasources = v.(bool)
+	if teams == nil {
+		permissionsToResourceData(d, permissions)
+		return d.Set("effective_permissions", []interface{}{permissionsToSchema(permissions)})
 	}
-	if v, ok := d.GetOk("data_manage_datafeeds"); ok {
-		p.Data.ManageDatafeeds = v.(bool)
-	}
-	if v, ok := d.GetOk("account_manage_users"); ok {
-		p.Account.ManageUsers = v.(bool)
-	}
-	if v, ok := d.GetOk("account_manage_payment_methods"); ok {
-		p.Account.ManagePaymentMethods = v.(bool)
-	}
-	if v, ok := d.GetOk("account_manage_plan"); ok {
-		p.Account.ManagePlan = v.(bool)
+
+	own := memberOwnPermissions(permissions, *teams, resourceDataToPermissions(d))
+	permissionsToResourceData(d, own)
+	return d.Set("effective_permissions", []interface{}{permissionsToSchema(unionPermissions(own, *teams))})
+}
+
+// memberOwnPermissions returns the own permissions of a member of teams, from
+// the permissions NS1 reports for it, the union of the permissions of its
+// teams and its permissions in the state. A grant is its own if it is
+// reported and no team gives it, or if it is reported and in the state, so a
+// grant of the state that is no longer reported shows as drift.
+func memberOwnPermissions(reported, teams, state account.PermissionsMap) account.PermissionsMap {
+	r, t, st := permissionsToSchema(reported), permissionsToSchema(teams), permissionsToSchema(state)
+	own := make(map[string]interface{}, len(r))
+	for name := range r {
+		rs, ts, ss := firstMap(r[name]), firstMap(t[name]), firstMap(st[name])
+		section := make(map[string]interface{}, len(rs))
+		for k, v := range rs {
+			switch v := v.(type) {
+			case bool:
+				section[k] = v && (!ts[k].(bool) || ss[k].(bool))
+			case []interface{}:
+				out := make([]interface{}, 0, len(v))
+				for _, e := range v {
+					if !containsValue(ts[k].([]interface{}), e) || containsValue(ss[k].([]interface{}), e) {
+						out = append(out, e)
+					}
+				}
+				section[k] = out
+			}
+		}
+		own[name] = []interface{}{section}
 	}
-	if v, ok := d.GetOk("account_manage_teams"); ok {
-		p.Account.ManageTeams = v.(bool)
+	return schemaToPermissions([]interface{}{own})
+}
+
+func containsValue(l []interface{}, v interface{}) bool {
+	for _, e := range l {
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
 	}
-	if v, ok := d.GetOk("account_manage_apikeys"); ok {
-		p.Account.ManageApikeys = v.(bool)
+	return false
+}
+
+func resourceDataToPermissions(d *schema.ResourceData) account.PermissionsMap {
+	return schemaToPermissions(d.Get("permissions"))
+}
+
+// permissionsToSchema returns every section of the permissions block.
+func permissionsToSchema(p account.PermissionsMap) map[string]interface{} {
+	security := securityPermissions(p)
+
+	return map[string]interface{}{
+		"dns": []interface{}{map[string]interface{}{
+			"view_zones":             p.DNS.ViewZones,
+			"manage_zones":           p.DNS.ManageZones,
+			"zones_allow_by_default": p.DNS.ZonesAllowByDefault,
+			"zones_allow":            stringsToSchema(p.DNS.ZonesAllow),
+			"zones_deny":             stringsToSchema(p.DNS.ZonesDeny),
+			"records_allow":          dnsRecordsACLtoSchema(p.DNS.RecordsAllow),
+			"records_deny":           dnsRecordsACLtoSchema(p.DNS.RecordsDeny),
+		}},
+		"data": []interface{}{map[string]interface{}{
+			"push_to_datafeeds":  p.Data.PushToDatafeeds,
+			"manage_datasources": p.Data.ManageDatasources,
+			"manage_datafeeds":   p.Data.ManageDatafeeds,
+		}},
+		"account": []interface{}{map[string]interface{}{
+			"manage_users":            p.Account.ManageUsers,
+			"manage_payment_methods":  p.Account.ManagePaymentMethods,
+			"manage_plan":             p.Account.ManagePlan,
+			"manage_teams":            p.Account.ManageTeams,
+			"manage_apikeys":          p.Account.ManageApikeys,
+			"manage_account_settings": p.Account.ManageAccountSettings,
+			"view_activity_log":       p.Account.ViewActivityLog,
+			"view_invoices":           p.Account.ViewInvoices,
+			"manage_ip_whitelist":     p.Account.ManageIPWhitelist,
+		}},
+		"monitoring": []interface{}{map[string]interface{}{
+			"manage_lists": p.Monitoring.ManageLists,
+			"manage_jobs":  p.Monitoring.ManageJobs,
+			"create_jobs":  p.Monitoring.CreateJobs,
+			"update_jobs":  p.Monitoring.UpdateJobs,
+			"delete_jobs":  p.Monitoring.DeleteJobs,
+			"view_jobs":    p.Monitoring.ViewJobs,
+		}},
+		"security": []interface{}{map[string]interface{}{
+			"manage_global_2fa":       security.ManageGlobal2FA,
+			"manage_active_directory": security.ManageActiveDirectory,
+		}},
+		"redirects": []interface{}{map[string]interface{}{
+			"manage_redirects": p.Redirects.ManageRedirects,
+		}},
+		"insights": []interface{}{map[string]interface{}{
+			"view_insights":   p.Insights.ViewInsights,
+			"manage_insights": p.Insights.ManageInsights,
+		}},
 	}
-	if v, ok := d.GetOk("account_manage_account_settings"); ok {
-		p.Account.ManageAccountSettings = v.(bool)
+}
+
+// schemaToPermissions converts a permissions block. Missing sections and
+// fields take their defaults.
+func schemaToPermissions(v interface{}) account.PermissionsMap {
+	p := account.PermissionsMap{
+		Security: &account.PermissionsSecurity{ManageGlobal2FA: true, ManageActiveDirectory: true},
+	}
+	p.DNS.ZonesAllow = []string{}
+	p.DNS.ZonesDeny = []string{}
+	p.DNS.RecordsAllow = []account.PermissionsRecord{}
+	p.DNS.RecordsDeny = []account.PermissionsRecord{}
+
+	m := firstMap(v)
+	if dns := firstMap(m["dns"]); dns != nil {
+		p.DNS.ViewZones = boolValue(dns["view_zones"], false)
+		p.DNS.ManageZones = boolValue(dns["manage_zones"], false)
+		p.DNS.ZonesAllowByDefault = boolValue(dns["zones_allow_by_default"], false)
+		p.DNS.ZonesAllow = schemaToStrings(dns["zones_allow"])
+		p.DNS.ZonesDeny = schemaToStrings(dns["zones_deny"])
+		p.DNS.RecordsAllow = schemaToDNSRecordsACL(dns["records_allow"])
+		p.DNS.RecordsDeny = schemaToDNSRecordsACL(dns["records_deny"])
+	}
+	if data := firstMap(m["data"]); data != nil {
+		p.Data.PushToDatafeeds = boolValue(data["push_to_datafeeds"], false)
+		p.Data.ManageDatasources = boolValue(data["manage_datasources"], false)
+		p.Data.ManageDatafeeds = boolValue(data["manage_datafeeds"], false)
+	}
+	if acc := firstMap(m["account"]); acc != nil {
+		p.Account.ManageUsers = boolValue(acc["manage_users"], false)
+		p.Account.ManagePaymentMethods = boolValue(acc["manage_payment_methods"], false)
+		p.Account.ManagePlan = boolValue(acc["manage_plan"], false)
+		p.Account.ManageTeams = boolValue(acc["manage_teams"], false)
+		p.Account.ManageApikeys = boolValue(acc["manage_apikeys"], false)
+		p.Account.ManageAccountSettings = boolValue(acc["manage_account_settings"], false)
+		p.Account.ViewActivityLog = boolValue(acc["view_activity_log"], false)
+		p.Account.ViewInvoices = boolValue(acc["view_invoices"], false)
+		p.Account.ManageIPWhitelist = boolValue(acc["manage_ip_whitelist"], false)
+	}
+	if mon := firstMap(m["monitoring"]); mon != nil {
+		p.Monitoring.ManageLists = boolValue(mon["manage_lists"], false)
+		p.Monitoring.ManageJobs = boolValue(mon["manage_jobs"], false)
+		p.Monitoring.CreateJobs = boolValue(mon["create_jobs"], false)
+		p.Monitoring.UpdateJobs = boolValue(mon["update_jobs"], false)
+		p.Monitoring.DeleteJobs = boolValue(mon["delete_jobs"], false)
+		p.Monitoring.ViewJobs = boolValue(mon["view_jobs"], false)
+	}
+	if sec := firstMap(m["security"]); sec != nil {
+		p.Security.ManageGlobal2FA = boolValue(sec["manage_global_2fa"], true)
+		p.Security.ManageActiveDirectory = boolValue(sec["manage_active_directory"], true)
+	}
+	if red := firstMap(m["redirects"]); red != nil {
+		p.Redirects.ManageRedirects = boolValue(red["manage_redirects"], false)
+	}
+	if ins := firstMap(m["insights"]); ins != nil {
+		p.Insights.ViewInsights = boolValue(ins["view_insights"], false)
+		p.Insights.ManageInsights = boolValue(ins["manage_insights"], false)
 	}
-	if v, ok := d.GetOk("account_view_activity_log"); ok {
-		p.Account.ViewActivityLog = v.(bool)
+	return p
+}
+
+// isDefaultPermissionSection reports whether a section of the permissions
+// block only holds default values.
+func isDefaultPermissionSection(name string, section interface{}) bool {
+	m, _ := section.(map[string]interface{})
+	defaults := firstMap(permissionsToSchema(schemaToPermissions(nil))[name])
+	for k, def := range defaults {
+		v, ok := m[k]
+		if !ok || v == nil {
+			continue
+		}
+		switch def.(type) {
+		case bool:
+			if v != def {
+				return false
+			}
+		default:
+			if l, ok := v.([]interface{}); ok && len(l) > 0 {
+				return false
+			}
+		}
 	}
-	if v, ok := d.GetOk("account_view_invoices"); ok {
-		p.Account.ViewInvoices = v.(bool)
+	return true
+}
+
+// unionPermissions returns the permissions granted by either a or b. A zone
+// stays denied only if both deny it, and a record only if both deny it.
+func unionPermissions(a, b account.PermissionsMap) account.PermissionsMap {
+	var p account.PermissionsMap
+
+	p.DNS.ViewZones = a.DNS.ViewZones || b.DNS.ViewZones
+	p.DNS.ManageZones = a.DNS.ManageZones || b.DNS.ManageZones
+	p.DNS.ZonesAllowByDefault = a.DNS.ZonesAllowByDefault || b.DNS.ZonesAllowByDefault
+	p.DNS.ZonesAllow = unionStrings(a.DNS.ZonesAllow, b.DNS.ZonesAllow)
+	// The deny list of a member that does not allow zones by default denies
+	// nothing it does not already.
+	switch {
+	case a.DNS.ZonesAllowByDefault && b.DNS.ZonesAllowByDefault:
+		p.DNS.ZonesDeny = intersectStrings(a.DNS.ZonesDeny, b.DNS.ZonesDeny)
+	case a.DNS.ZonesAllowByDefault:
+		p.DNS.ZonesDeny = a.DNS.ZonesDeny
+	case b.DNS.ZonesAllowByDefault:
+		p.DNS.ZonesDeny = b.DNS.ZonesDeny
+	}
+	p.DNS.ZonesDeny = subtractStrings(p.DNS.ZonesDeny, p.DNS.ZonesAllow)
+	p.DNS.RecordsAllow = unionRecords(a.DNS.RecordsAllow, b.DNS.RecordsAllow)
+	p.DNS.RecordsDeny = intersectRecords(a.DNS.RecordsDeny, b.DNS.RecordsDeny)
+
+	p.Data.PushToDatafeeds = a.Data.PushToDatafeeds || b.Data.PushToDatafeeds
+	p.Data.ManageDatasources = a.Data.ManageDatasources || b.Data.ManageDatasources
+	p.Data.ManageDatafeeds = a.Data.ManageDatafeeds || b.Data.ManageDatafeeds
+
+	p.Account.ManageUsers = a.Account.ManageUsers || b.Account.ManageUsers
+	p.Account.ManagePaymentMethods = a.Account.ManagePaymentMethods || b.Account.ManagePaymentMethods
+	p.Account.ManagePlan = a.Account.ManagePlan || b.Account.ManagePlan
+	p.Account.ManageTeams = a.Account.ManageTeams || b.Account.ManageTeams
+	p.Account.ManageApikeys = a.Account.ManageApikeys || b.Account.ManageApikeys
+	p.Account.ManageAccountSettings = a.Account.ManageAccountSettings || b.Account.ManageAccountSettings
+	p.Account.ViewActivityLog = a.Account.ViewActivityLog || b.Account.ViewActivityLog
+	p.Account.ViewInvoices = a.Account.ViewInvoices || b.Account.ViewInvoices
+	p.Account.ManageIPWhitelist = a.Account.ManageIPWhitelist || b.Account.ManageIPWhitelist
+
+	p.Monitoring.ManageLists = a.Monitoring.ManageLists || b.Monitoring.ManageLists
+	p.Monitoring.ManageJobs = a.Monitoring.ManageJobs || b.Monitoring.ManageJobs
+	p.Monitoring.CreateJobs = a.Monitoring.CreateJobs || b.Monitoring.CreateJobs
+	p.Monitoring.UpdateJobs = a.Monitoring.UpdateJobs || b.Monitoring.UpdateJobs
+	p.Monitoring.DeleteJobs = a.Monitoring.DeleteJobs || b.Monitoring.DeleteJobs
+	p.Monitoring.ViewJobs = a.Monitoring.ViewJobs || b.Monitoring.ViewJobs
+
+	as, bs := securityPermissions(a), securityPermissions(b)
+	p.Security = &account.PermissionsSecurity{
+		ManageGlobal2FA:       as.ManageGlobal2FA || bs.ManageGlobal2FA,
+		ManageActiveDirectory: as.ManageActiveDirectory || bs.ManageActiveDirectory,
 	}
-	if v, ok := d.GetOk("account_manage_ip_whitelist"); ok {
-		p.Account.ManageIPWhitelist = v.(bool)
+
+	p.Redirects.ManageRedirects = a.Redirects.ManageRedirects || b.Redirects.ManageRedirects
+
+	p.Insights.ViewInsights = a.Insights.ViewInsights || b.Insights.ViewInsights
+	p.Insights.ManageInsights = a.Insights.ManageInsights || b.Insights.ManageInsights
+	return p
+}
+
+// securityPermissions returns the security permissions of p, which are on
+// unless NS1 reports them.
+func securityPermissions(p account.PermissionsMap) account.PermissionsSecurity {
+	if p.Security == nil {
+		return account.PermissionsSecurity{ManageGlobal2FA: true, ManageActiveDirectory: true}
 	}
-	if v, ok := d.GetOk("monitoring_manage_lists"); ok {
-		p.Monitoring.ManageLists = v.(bool)
+	return *p.Security
+}
+
+func unionStrings(a, b []string) []string {
+	out := append([]string{}, a...)
+	return append(out, subtractStrings(b, a)...)
+}
+
+func intersectStrings(a, b []string) []string {
+	out := []string{}
+	for _, s := range a {
+		if containsString(b, s) {
+			out = append(out, s)
+		}
 	}
-	if v, ok := d.GetOk("monitoring_manage_jobs"); ok {
-		p.Monitoring.ManageJobs = v.(bool)
+	return out
+}
+
+func subtractStrings(a, b []string) []string {
+	out := []string{}
+	for _, s := range a {
+		if !containsString(b, s) {
+			out = append(out, s)
+		}
 	}
-	if v, ok := d.GetOk("monitoring_create_jobs"); ok {
-		p.Monitoring.CreateJobs = v.(bool)
+	return out
+}
+
+func containsString(l []string, s string) bool {
+	for _, v := range l {
+		if v == s {
+			return true
+		}
 	}
-	if v, ok := d.GetOk("monitoring_update_jobs"); ok {
-		p.Monitoring.UpdateJobs = v.(bool)
+	return false
+}
+
+func unionRecords(a, b []account.PermissionsRecord) []account.PermissionsRecord {
+	out := append([]account.PermissionsRecord{}, a...)
+	for _, r := range b {
+		if !containsRecord(a, r) {
+			out = append(out, r)
+		}
 	}
-	if v, ok := d.GetOk("monitoring_delete_jobs"); ok {
-		p.Monitoring.DeleteJobs = v.(bool)
+	return out
+}
+
+func intersectRecords(a, b []account.PermissionsRecord) []account.PermissionsRecord {
+	out := []account.PermissionsRecord{}
+	for _, r := range a {
+		if containsRecord(b, r) {
+			out = append(out, r)
+		}
 	}
-	if v, ok := d.GetOk("monitoring_view_jobs"); ok {
-		p.Monitoring.ViewJobs = v.(bool)
+	return out
+}
+
+func containsRecord(l []account.PermissionsRecord, r account.PermissionsRecord) bool {
+	for _, v := range l {
+		if v == r {
+			return true
+		}
 	}
-	if p.Security == nil {
-		p.Security = &account.PermissionsSecurity{}
+	return false
+}
+
+// permissionNames returns the boolean permissions, as "section.field".
+func permissionNames() []string {
+	var names []string
+	for section, s := range permissionsSchema().Elem.(*schema.Resource).Schema {
+		for field, f := range s.Elem.(*schema.Resource).Schema {
+			if f.Type == schema.TypeBool {
+				names = append(names, section+"."+field)
+			}
+		}
 	}
-	if v, ok := d.GetOk("security_manage_global_2fa"); ok {
-		p.Security.ManageGlobal2FA = v.(bool)
+	sort.Strings(names)
+	return names
+}
+
+// hasPermission reports whether p grants a boolean permission, given as
+// "section.field".
+func hasPermission(p account.PermissionsMap, name string) bool {
+	parts := strings.SplitN(name, ".", 2)
+	if len(parts) != 2 {
+		return false
 	}
-	if v, ok := d.GetOk("security_manage_active_directory"); ok {
-		p.Security.ManageActiveDirectory = v.(bool)
+	v, _ := firstMap(permissionsToSchema(p)[parts[0]])[parts[1]].(bool)
+	return v
+}
+
+// firstMap returns the single element of a MaxItems: 1 block, or nil.
+func firstMap(v interface{}) map[string]interface{} {
+	if l, ok := v.([]interface{}); ok && len(l) > 0 {
+		m, _ := l[0].(map[string]interface{})
+		return m
 	}
-	if v, ok := d.GetOk("redirects_manage_redirects"); ok {
-		p.Redirects.ManageRedirects = v.(bool)
+	return nil
+}
+
+func boolValue(v interface{}, def bool) bool {
+	if b, ok := v.(bool); ok {
+		return b
 	}
-	if v, ok := d.GetOk("insights_view_insights"); ok {
-		p.Insights.ViewInsights = v.(bool)
+	return def
+}
+
+func stringsToSchema(s []string) []interface{} {
+	out := make([]interface{}, len(s))
+	for i, v := range s {
+		out[i] = v
 	}
-	if v, ok := d.GetOk("insights_manage_insights"); ok {
-		p.Insights.ManageInsights = v.(bool)
+	return out
+}
+
+func schemaToStrings(v interface{}) []string {
+	raw, _ := v.([]interface{})
+	out := make([]string, 0, len(raw))
+	for _, s := range raw {
+		if str, ok := s.(string); ok {
+			out = append(out, str)
+		}
 	}
-	return p
+	return out
 }
 
-func dnsRecordsACLtoSchema(acls []account.PermissionsRecord) []map[string]interface{} {
-	results := make([]map[string]interface{}, len(acls))
+func dnsRecordsACLtoSchema(acls []account.PermissionsRecord) []interface{} {
+	results := make([]interface{}, len(acls))
 	for i, r := range acls {
 		m := make(map[string]interface{})
 		m["domain"] = r.Domain
@@ -408,10 +600,13 @@ func dnsRecordsACLtoSchema(acls []account.PermissionsRecord) []map[string]interf
 }
 
 func schemaToDNSRecordsACL(v interface{}) []account.PermissionsRecord {
+	records := []account.PermissionsRecord{}
 	if schemaRecord, ok := v.([]interface{}); ok {
-		var records []account.PermissionsRecord
 		for _, sr := range schemaRecord {
-			mapRecord := sr.(map[string]interface{})
+			mapRecord, ok := sr.(map[string]interface{})
+			if !ok {
+				continue
+			}
 			record := account.PermissionsRecord{
 				Domain:     mapRecord["domain"].(string),
 				Subdomains: mapRecord["include_subdomains"].(bool),
@@ -419,7 +614,6 @@ func schemaToDNSRecordsACL(v interface{}) []account.PermissionsRecord {
 				RecordType: mapRecord["type"].(string)}
 			records = append(records, record)
 		}
-		return records
 	}
-	return []account.PermissionsRecord{}
+	return records
 }
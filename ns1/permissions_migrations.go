@@ -13,24 +13,272 @@ func permissionInstanceStateUpgradeV0(ctx context.Context, rawState map[string]i
 	return rawState, nil
 }
 
+// permissionFieldsV1 maps the sections and fields of the permissions block to
+// the flat attributes they replaced.
+var permissionFieldsV1 = map[string]map[string]string{
+	"dns": {
+		"view_zones":             "dns_view_zones",
+		"manage_zones":           "dns_manage_zones",
+		"zones_allow_by_default": "dns_zones_allow_by_default",
+		"zones_allow":            "dns_zones_allow",
+		"zones_deny":             "dns_zones_deny",
+		"records_allow":          "dns_records_allow",
+		"records_deny":           "dns_records_deny",
+	},
+	"data": {
+		"push_to_datafeeds":  "data_push_to_datafeeds",
+		"manage_datasources": "data_manage_datasources",
+		"manage_datafeeds":   "data_manage_datafeeds",
+	},
+	"account": {
+		"manage_users":            "account_manage_users",
+		"manage_payment_methods":  "account_manage_payment_methods",
+		"manage_plan":             "account_manage_plan",
+		"manage_teams":            "account_manage_teams",
+		"manage_apikeys":          "account_manage_apikeys",
+		"manage_account_settings": "account_manage_account_settings",
+		"view_activity_log":       "account_view_activity_log",
+		"view_invoices":           "account_view_invoices",
+		"manage_ip_whitelist":     "account_manage_ip_whitelist",
+	},
+	"monitoring": {
+		"manage_lists": "monitoring_manage_lists",
+		"manage_jobs":  "monitoring_manage_jobs",
+		"create_jobs":  "monitoring_create_jobs",
+		"update_jobs":  "monitoring_update_jobs",
+		"delete_jobs":  "monitoring_delete_jobs",
+		"view_jobs":    "monitoring_view_jobs",
+	},
+	"security": {
+		"manage_global_2fa":       "security_manage_global_2fa",
+		"manage_active_directory": "security_manage_active_directory",
+	},
+	"redirects": {
+		"manage_redirects": "redirects_manage_redirects",
+	},
+	"insights": {
+		"view_insights":   "insights_view_insights",
+		"manage_insights": "insights_manage_insights",
+	},
+}
+
+// permissionInstanceStateUpgradeV1 moves the flat permission attributes into
+// the permissions block. Sections left at their defaults are dropped, as
+// they are on read.
+func permissionInstanceStateUpgradeV1(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	defaults := permissionsToSchema(schemaToPermissions(nil))
+	permissions := make(map[string]interface{})
+	for section, fields := range permissionFieldsV1 {
+		m := make(map[string]interface{}, len(fields))
+		for field, key := range fields {
+			if v, ok := rawState[key]; ok && v != nil {
+				m[field] = v
+			} else {
+				m[field] = firstMap(defaults[section])[field]
+			}
+			delete(rawState, key)
+		}
+		if !isDefaultPermissionSection(section, m) {
+			permissions[section] = []interface{}{m}
+		}
+	}
+
+	if len(permissions) > 0 {
+		rawState["permissions"] = []interface{}{permissions}
+	} else {
+		rawState["permissions"] = []interface{}{}
+	}
+
+	return rawState, nil
+}
+
+func addPermsSchemaV1(s map[string]*schema.Schema) map[string]*schema.Schema {
+	dnsRecords := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Required: false,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"domain": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"include_subdomains": {
+					Type:     schema.TypeBool,
+					Required: true,
+				},
+				"zone": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"type": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+			},
+		},
+	}
+	s["dns_records_allow"] = dnsRecords
+	s["dns_records_deny"] = dnsRecords
+	s["dns_view_zones"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["dns_manage_zones"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["dns_zones_allow_by_default"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["dns_zones_deny"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	s["dns_zones_allow"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	s["data_push_to_datafeeds"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["data_manage_datasources"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["data_manage_datafeeds"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["account_manage_users"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["account_manage_payment_methods"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["account_manage_plan"] = &schema.Schema{
+		Type:       schema.TypeBool,
+		Optional:   true,
+		Default:    false,
+		Deprecated: "obsolete, should no longer be used",
+	}
+	s["account_manage_teams"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["account_manage_apikeys"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["account_manage_account_settings"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["account_view_activity_log"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["account_view_invoices"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["account_manage_ip_whitelist"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["monitoring_manage_lists"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["monitoring_manage_jobs"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["monitoring_create_jobs"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["monitoring_update_jobs"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["monitoring_delete_jobs"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["monitoring_view_jobs"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["security_manage_global_2fa"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
+	}
+	s["security_manage_active_directory"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
+	}
+	s["redirects_manage_redirects"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["insights_view_insights"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["insights_manage_insights"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	return s
+}
+
 func addPermsSchemaV0(s map[string]*schema.Schema) map[string]*schema.Schema {
 	s["dns_view_zones"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["dns_manage_zones"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["dns_zones_allow_by_default"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["dns_zones_deny"] = &schema.Schema{
 		Type:     schema.TypeList,
@@ -43,100 +291,84 @@ func addPermsSchemaV0(s map[string]*schema.Schema) map[string]*schema.Schema {
 		Elem:     &schema.Schema{Type: schema.TypeString},
 	}
 	s["data_push_to_datafeeds"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["data_manage_datasources"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["data_manage_datafeeds"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["account_manage_users"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["account_manage_payment_methods"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["account_manage_plan"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["account_manage_teams"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["account_manage_apikeys"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["account_manage_account_settings"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["account_view_activity_log"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["account_view_invoices"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["account_manage_ip_whitelist"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["monitoring_manage_lists"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["monitoring_manage_jobs"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["monitoring_view_jobs"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	s["redirects_manage_redirects"] = &schema.Schema{
-		Type:             schema.TypeBool,
-		Optional:         true,
-		Default:          false,
-		DiffSuppressFunc: suppressPermissionDiff,
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
 	}
 	return s
 }
@@ -0,0 +1,143 @@
+package ns1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+// addRecordsACLSchema adds the optional check of the zones of the records
+// ACLs, and the records they match.
+func addRecordsACLSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["verify_records_acl"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["records_allow_matches"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	s["records_deny_matches"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	return s
+}
+
+// recordsACLCustomizeDiff checks that the domain of each records_allow and
+// records_deny entry is within its zone.
+func recordsACLCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, acl := range []string{"records_allow", "records_deny"} {
+		key := "permissions.0.dns.0." + acl
+		if !d.NewValueKnown(key) {
+			continue
+		}
+		raw, _ := d.Get(key).([]interface{})
+		for i, r := range raw {
+			m, ok := r.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			domain, zone := m["domain"].(string), m["zone"].(string)
+			if domain == "" || zone == "" {
+				continue
+			}
+			if !domainInZone(domain, zone) {
+				return fmt.Errorf("%s.%d: domain %q is not within zone %q", key, i, domain, zone)
+			}
+		}
+	}
+	return nil
+}
+
+func normalizeDomain(s string) string {
+	return strings.ToLower(strings.TrimSuffix(s, "."))
+}
+
+// domainInZone reports whether domain is the zone apex or a name below it.
+func domainInZone(domain, zone string) bool {
+	domain, zone = normalizeDomain(domain), normalizeDomain(zone)
+	return domain == zone || strings.HasSuffix(domain, "."+zone)
+}
+
+// recordACLMatches reports whether the record domain/type of a zone is
+// covered by an ACL entry.
+func recordACLMatches(acl account.PermissionsRecord, zone, domain, rtype string) bool {
+	if normalizeDomain(acl.Zone) != normalizeDomain(zone) || acl.RecordType != rtype {
+		return false
+	}
+	domain, aclDomain := normalizeDomain(domain), normalizeDomain(acl.Domain)
+	return domain == aclDomain || (acl.Subdomains && strings.HasSuffix(domain, "."+aclDomain))
+}
+
+func recordsACLZones(p account.PermissionsMap) []string {
+	var zones []string
+	for _, r := range append(append([]account.PermissionsRecord{}, p.DNS.RecordsAllow...), p.DNS.RecordsDeny...) {
+		zones = unionStrings(zones, []string{r.Zone})
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// verifyRecordsACL checks that the zones of the records ACLs exist, when
+// verify_records_acl is set.
+func verifyRecordsACL(d *schema.ResourceData, client *ns1.Client) error {
+	if !d.Get("verify_records_acl").(bool) {
+		return nil
+	}
+	for _, zone := range recordsACLZones(resourceDataToPermissions(d)) {
+		if _, resp, err := client.Zones.Get(zone, false); err != nil {
+			if err == ns1.ErrZoneMissing {
+				return fmt.Errorf("zone %s of the records ACL does not exist", zone)
+			}
+			return ConvertToNs1Error(resp, err)
+		}
+	}
+	return nil
+}
+
+// recordsACLMatchesToResourceData sets the IDs of the existing records the
+// records ACLs match, when verify_records_acl is set. Zones that are missing
+// match no records.
+func recordsACLMatchesToResourceData(d *schema.ResourceData, client *ns1.Client) error {
+	allow, deny := []string{}, []string{}
+	if d.Get("verify_records_acl").(bool) {
+		p := resourceDataToPermissions(d)
+		for _, zone := range recordsACLZones(p) {
+			z, resp, err := client.Zones.Get(zone, true)
+			if err != nil {
+				if err == ns1.ErrZoneMissing {
+					continue
+				}
+				return ConvertToNs1Error(resp, err)
+			}
+			for _, r := range z.Records {
+				id := fmt.Sprintf("%s/%s/%s", z.Zone, r.Domain, r.Type)
+				for _, acl := range p.DNS.RecordsAllow {
+					if recordACLMatches(acl, z.Zone, r.Domain, r.Type) {
+						allow = unionStrings(allow, []string{id})
+					}
+				}
+				for _, acl := range p.DNS.RecordsDeny {
+					if recordACLMatches(acl, z.Zone, r.Domain, r.Type) {
+						deny = unionStrings(deny, []string{id})
+					}
+				}
+			}
+		}
+		sort.Strings(allow)
+		sort.Strings(deny)
+	}
+	d.Set("records_allow_matches", allow)
+	d.Set("records_deny_matches", deny)
+	return nil
+}
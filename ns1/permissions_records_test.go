@@ -0,0 +1,180 @@
+package ns1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+func TestAccTeam_recordsACLMatches(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	zoneName := fmt.Sprintf("terraform-test-%s.io", rString)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTeamDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamRecordsACL(rString, zoneName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ns1_team.t", "records_allow_matches.#", "2"),
+					resource.TestCheckResourceAttr("ns1_team.t", "records_allow_matches.0", fmt.Sprintf("%[1]s/api.%[1]s/A", zoneName)),
+					resource.TestCheckResourceAttr("ns1_team.t", "records_allow_matches.1", fmt.Sprintf("%[1]s/v1.api.%[1]s/A", zoneName)),
+					resource.TestCheckResourceAttr("ns1_team.t", "records_deny_matches.#", "0"),
+				),
+			},
+			{
+				Config:      testAccTeamRecordsACL(rString, "missing-"+zoneName),
+				ExpectError: regexp.MustCompile(`is not within zone`),
+			},
+		},
+	})
+}
+
+func TestAccTeam_recordsACLMissingZone(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTeamDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`resource "ns1_team" "t" {
+  name               = "terraform acc test team %[1]s"
+  verify_records_acl = true
+
+  permissions {
+    dns {
+      records_allow {
+        domain             = "www.missing-%[1]s.io"
+        include_subdomains = false
+        zone               = "missing-%[1]s.io"
+        type               = "A"
+      }
+    }
+  }
+}
+`, rString),
+				ExpectError: regexp.MustCompile(`of the records ACL does not exist`),
+			},
+		},
+	})
+}
+
+func TestDomainInZone(t *testing.T) {
+	assert.True(t, domainInZone("example.com", "example.com"))
+	assert.True(t, domainInZone("www.Example.com.", "example.com"))
+	assert.True(t, domainInZone("a.b.example.com", "example.com."))
+	assert.False(t, domainInZone("example.com", "www.example.com"))
+	assert.False(t, domainInZone("badexample.com", "example.com"))
+	assert.False(t, domainInZone("www.example.org", "example.com"))
+}
+
+func TestRecordACLMatches(t *testing.T) {
+	acl := account.PermissionsRecord{Domain: "api.example.com", Zone: "example.com", RecordType: "A"}
+	assert.True(t, recordACLMatches(acl, "example.com", "api.example.com", "A"))
+	assert.False(t, recordACLMatches(acl, "example.com", "v1.api.example.com", "A"))
+	assert.False(t, recordACLMatches(acl, "example.com", "api.example.com", "AAAA"))
+	assert.False(t, recordACLMatches(acl, "example.org", "api.example.com", "A"))
+
+	acl.Subdomains = true
+	assert.True(t, recordACLMatches(acl, "example.com", "v1.api.example.com", "A"))
+	assert.False(t, recordACLMatches(acl, "example.com", "myapi.example.com", "A"))
+}
+
+func TestRecordsACLValidation(t *testing.T) {
+	config := func(domain, zone, rtype string) map[string]interface{} {
+		return map[string]interface{}{
+			"name": "team",
+			"permissions": []interface{}{map[string]interface{}{
+				"dns": []interface{}{map[string]interface{}{
+					"records_allow": []interface{}{map[string]interface{}{
+						"domain":             domain,
+						"include_subdomains": false,
+						"zone":               zone,
+						"type":               rtype,
+					}},
+				}},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+		errs   bool
+	}{
+		{"valid", config("www.example.com", "example.com", "A"), false},
+		{"apex", config("example.com", "example.com", "MX"), false},
+		{"bad type", config("www.example.com", "example.com", "Z"), true},
+		{"lowercase type", config("www.example.com", "example.com", "cname"), true},
+	}
+	r := teamResource()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := r.Validate(terraform.NewResourceConfigRaw(tt.config))
+			assert.Equal(t, tt.errs, diags.HasError())
+		})
+	}
+
+	state := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "team"})
+	state.SetId("abc")
+	_, err := r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(config("www.example.org", "example.com", "A")), nil)
+	assert.Error(t, err)
+	_, err = r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(config("www.example.com", "example.com", "A")), nil)
+	assert.NoError(t, err)
+}
+
+func testAccTeamRecordsACL(rString, aclZone string) string {
+	return fmt.Sprintf(`resource "ns1_zone" "z" {
+  zone = "%[2]s"
+}
+
+resource "ns1_record" "api" {
+  zone   = ns1_zone.z.zone
+  domain = "api.${ns1_zone.z.zone}"
+  type   = "A"
+  answers {
+    answer = "1.1.1.1"
+  }
+}
+
+resource "ns1_record" "v1" {
+  zone   = ns1_zone.z.zone
+  domain = "v1.api.${ns1_zone.z.zone}"
+  type   = "A"
+  answers {
+    answer = "1.1.1.2"
+  }
+}
+
+resource "ns1_team" "t" {
+  name               = "terraform acc test team %[1]s"
+  verify_records_acl = true
+
+  permissions {
+    dns {
+      records_allow {
+        domain             = "api.%[2]s"
+        include_subdomains = true
+        zone               = "%[3]s"
+        type               = "A"
+      }
+    }
+  }
+
+  depends_on = [ns1_record.api, ns1_record.v1]
+}
+`, rString, "terraform-test-"+rString+".io", aclZone)
+}
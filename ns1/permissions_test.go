@@ -0,0 +1,303 @@
+package ns1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+func TestPermissionsRoundTrip(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, teamResource().Schema, map[string]interface{}{
+		"name": "team",
+		"permissions": []interface{}{map[string]interface{}{
+			"dns": []interface{}{map[string]interface{}{
+				"view_zones":  true,
+				"zones_allow": []interface{}{"mytest.zone"},
+				"records_allow": []interface{}{map[string]interface{}{
+					"domain":             "my.ns1.com",
+					"include_subdomains": false,
+					"zone":               "ns1.com",
+					"type":               "A",
+				}},
+			}},
+			"monitoring": []interface{}{map[string]interface{}{
+				"view_jobs": true,
+			}},
+		}},
+	})
+
+	p := resourceDataToPermissions(d)
+	assert.True(t, p.DNS.ViewZones)
+	assert.False(t, p.DNS.ManageZones)
+	assert.Equal(t, []string{"mytest.zone"}, p.DNS.ZonesAllow)
+	assert.Equal(t, []string{}, p.DNS.ZonesDeny)
+	assert.Equal(t, []account.PermissionsRecord{
+		{Domain: "my.ns1.com", Subdomains: false, Zone: "ns1.com", RecordType: "A"},
+	}, p.DNS.RecordsAllow)
+	assert.Equal(t, []account.PermissionsRecord{}, p.DNS.RecordsDeny)
+	assert.True(t, p.Monitoring.ViewJobs)
+	// Sections that are not configured take their defaults.
+	assert.Equal(t, &account.PermissionsSecurity{ManageGlobal2FA: true, ManageActiveDirectory: true}, p.Security)
+
+	// Only sections that differ from the defaults are read into an empty state.
+	imported := schema.TestResourceDataRaw(t, teamResource().Schema, map[string]interface{}{"name": "team"})
+	permissionsToResourceData(imported, p)
+	assert.Equal(t, true, imported.Get("permissions.0.dns.0.view_zones"))
+	assert.Equal(t, "my.ns1.com", imported.Get("permissions.0.dns.0.records_allow.0.domain"))
+	assert.Equal(t, true, imported.Get("permissions.0.monitoring.0.view_jobs"))
+	assert.Equal(t, 0, imported.Get("permissions.0.data.#"))
+	assert.Equal(t, 0, imported.Get("permissions.0.security.#"))
+
+	// Sections already in the state are kept, even at their defaults.
+	p.Monitoring.ViewJobs = false
+	permissionsToResourceData(d, p)
+	assert.Equal(t, 1, d.Get("permissions.0.monitoring.#"))
+	assert.Equal(t, false, d.Get("permissions.0.monitoring.0.view_jobs"))
+
+	// No permissions at all leave the block out.
+	empty := schema.TestResourceDataRaw(t, teamResource().Schema, map[string]interface{}{"name": "team"})
+	permissionsToResourceData(empty, schemaToPermissions(nil))
+	assert.Equal(t, 0, empty.Get("permissions.#"))
+}
+
+func TestPermissionInstanceStateUpgradeV1(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":                               "abc",
+		"name":                             "team",
+		"dns_view_zones":                   true,
+		"dns_manage_zones":                 false,
+		"dns_zones_allow_by_default":       false,
+		"dns_zones_allow":                  []interface{}{"mytest.zone"},
+		"dns_zones_deny":                   []interface{}{},
+		"dns_records_allow":                []interface{}{},
+		"data_push_to_datafeeds":           false,
+		"data_manage_datasources":          false,
+		"account_manage_users":             false,
+		"monitoring_view_jobs":             false,
+		"security_manage_global_2fa":       true,
+		"security_manage_active_directory": true,
+		"insights_view_insights":           true,
+	}
+
+	state, err := permissionInstanceStateUpgradeV1(context.Background(), rawState, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "team", state["name"])
+	assert.NotContains(t, state, "dns_view_zones")
+	assert.NotContains(t, state, "insights_view_insights")
+
+	permissions := firstMap(state["permissions"])
+	assert.ElementsMatch(t, []string{"dns", "insights"}, keys(permissions))
+	dns := firstMap(permissions["dns"])
+	assert.Equal(t, true, dns["view_zones"])
+	assert.Equal(t, []interface{}{"mytest.zone"}, dns["zones_allow"])
+	// Fields missing from the old state take their defaults.
+	assert.Equal(t, []interface{}{}, dns["records_deny"])
+	assert.Equal(t, true, firstMap(permissions["insights"])["view_insights"])
+	assert.Equal(t, false, firstMap(permissions["insights"])["manage_insights"])
+}
+
+func keys(m map[string]interface{}) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
+
+func TestPermissionsNoDiffAfterRead(t *testing.T) {
+	config := map[string]interface{}{
+		"name": "team",
+		"permissions": []interface{}{map[string]interface{}{
+			"dns": []interface{}{map[string]interface{}{
+				"view_zones": true,
+			}},
+		}},
+	}
+
+	r := teamResource()
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	d.SetId("abc")
+	p := resourceDataToPermissions(d)
+
+	read := r.Data(nil)
+	read.SetId("abc")
+	read.Set("name", "team")
+	read.Set("verify_records_acl", false)
+	permissionsToResourceData(read, p)
+	assert.NoError(t, recordsACLMatchesToResourceData(read, nil))
+
+	diff, err := r.Diff(context.Background(), read.State(), terraform.NewResourceConfigRaw(config), nil)
+	assert.NoError(t, err)
+	if diff != nil {
+		assert.Empty(t, diff.Attributes)
+	}
+}
+
+// testAccCheckPermissionAttr checks a permission of a resource, given as
+// "section.field". Sections left out of the state hold their defaults.
+func testAccCheckPermissionAttr(name, key, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("not found: %s", name)
+		}
+
+		parts := strings.SplitN(key, ".", 2)
+		attr := fmt.Sprintf("permissions.0.%s.0.%s", parts[0], parts[1])
+		got, ok := rs.Primary.Attributes[attr]
+		if !ok {
+			got = fmt.Sprint(firstMap(permissionsToSchema(schemaToPermissions(nil))[parts[0]])[parts[1]])
+		}
+		if got != value {
+			return fmt.Errorf("%s: %s: got: %s want: %s", name, attr, got, value)
+		}
+		return nil
+	}
+}
+
+func TestUnionPermissions(t *testing.T) {
+	team := schemaToPermissions(nil)
+	team.Security = &account.PermissionsSecurity{}
+	team.DNS.ViewZones = true
+	team.DNS.ZonesAllowByDefault = true
+	team.DNS.ZonesDeny = []string{"a.zone", "b.zone"}
+	team.Account.ManageAccountSettings = true
+
+	own := schemaToPermissions(nil)
+	own.Security = &account.PermissionsSecurity{}
+	own.DNS.ZonesAllow = []string{"b.zone"}
+	own.DNS.RecordsDeny = []account.PermissionsRecord{{Domain: "x.a.zone", Zone: "a.zone", RecordType: "A"}}
+	own.Account.ManageIPWhitelist = true
+
+	p := unionPermissions(own, team)
+	assert.True(t, p.DNS.ViewZones)
+	assert.True(t, p.DNS.ZonesAllowByDefault)
+	assert.Equal(t, []string{"b.zone"}, p.DNS.ZonesAllow)
+	// b.zone is allowed by the member, so only a.zone stays denied.
+	assert.Equal(t, []string{"a.zone"}, p.DNS.ZonesDeny)
+	// Records denied by only one side are granted by the other.
+	assert.Equal(t, []account.PermissionsRecord{}, p.DNS.RecordsDeny)
+	assert.True(t, p.Account.ManageAccountSettings)
+	assert.True(t, p.Account.ManageIPWhitelist)
+	assert.False(t, p.Account.ManageUsers)
+	assert.Equal(t, &account.PermissionsSecurity{}, p.Security)
+
+	// Security permissions NS1 does not report are on.
+	own.Security = nil
+	p = unionPermissions(own, team)
+	assert.Equal(t, &account.PermissionsSecurity{ManageGlobal2FA: true, ManageActiveDirectory: true}, p.Security)
+}
+
+func TestPermissionsDiffWithTeams(t *testing.T) {
+	permissions := []interface{}{map[string]interface{}{
+		"account": []interface{}{map[string]interface{}{
+			"manage_ip_whitelist": true,
+		}},
+	}}
+
+	r := userResource()
+	state := r.Data(nil)
+	state.SetId("user")
+	state.Set("name", "user")
+	state.Set("username", "user")
+	state.Set("email", "user@example.com")
+	state.Set("teams", []interface{}{"team"})
+	state.Set("permissions", permissions)
+
+	config := map[string]interface{}{
+		"name":        "user",
+		"username":    "user",
+		"email":       "user@example.com",
+		"permissions": permissions,
+	}
+
+	// Leaving the team only changes the teams.
+	diff, err := r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(config), nil)
+	assert.NoError(t, err)
+	assert.Contains(t, diff.Attributes, "teams.#")
+	for k := range diff.Attributes {
+		assert.False(t, strings.HasPrefix(k, "permissions."), k)
+	}
+
+	// Permissions configured on a member of a team are diffed.
+	config["teams"] = []interface{}{"team"}
+	config["permissions"] = []interface{}{map[string]interface{}{
+		"account": []interface{}{map[string]interface{}{
+			"manage_ip_whitelist": false,
+		}},
+	}}
+	diff, err = r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(config), nil)
+	assert.NoError(t, err)
+	assert.Contains(t, diff.Attributes, "permissions.0.account.0.manage_ip_whitelist")
+}
+
+func TestMemberOwnPermissions(t *testing.T) {
+	team := schemaToPermissions(nil)
+	team.DNS.ViewZones = true
+	team.DNS.ZonesAllow = []string{"team.zone"}
+	team.Account.ManageAccountSettings = true
+
+	state := schemaToPermissions(nil)
+	state.DNS.ViewZones = true
+	state.Account.ManageIPWhitelist = true
+
+	// manage_ip_whitelist was revoked and manage_users granted outside of
+	// Terraform.
+	reported := schemaToPermissions(nil)
+	reported.DNS.ViewZones = true
+	reported.DNS.ZonesAllow = []string{"team.zone", "own.zone"}
+	reported.Account.ManageAccountSettings = true
+	reported.Account.ManageUsers = true
+
+	own := memberOwnPermissions(reported, team, state)
+	assert.True(t, own.DNS.ViewZones)
+	assert.Equal(t, []string{"own.zone"}, own.DNS.ZonesAllow)
+	assert.False(t, own.Account.ManageAccountSettings)
+	assert.True(t, own.Account.ManageUsers)
+	assert.False(t, own.Account.ManageIPWhitelist)
+
+	effective := unionPermissions(own, team)
+	assert.True(t, effective.Account.ManageAccountSettings)
+	assert.True(t, effective.Account.ManageUsers)
+	assert.Equal(t, []string{"own.zone", "team.zone"}, effective.DNS.ZonesAllow)
+
+	// The revoked grant shows as drift of a configured member of a team.
+	permissions := []interface{}{map[string]interface{}{
+		"dns": []interface{}{map[string]interface{}{
+			"view_zones": true,
+		}},
+		"account": []interface{}{map[string]interface{}{
+			"manage_ip_whitelist": true,
+		}},
+	}}
+	r := userResource()
+	d := r.Data(nil)
+	d.SetId("user")
+	d.Set("name", "user")
+	d.Set("username", "user")
+	d.Set("email", "user@example.com")
+	d.Set("teams", []interface{}{"team"})
+	d.Set("permissions", permissions)
+	permissionsToResourceData(d, memberOwnPermissions(reported, team, resourceDataToPermissions(d)))
+
+	config := map[string]interface{}{
+		"name":        "user",
+		"username":    "user",
+		"email":       "user@example.com",
+		"teams":       []interface{}{"team"},
+		"permissions": permissions,
+	}
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+	assert.NoError(t, err)
+	assert.Contains(t, diff.Attributes, "permissions.0.account.0.manage_ip_whitelist")
+	assert.Contains(t, diff.Attributes, "permissions.0.account.0.manage_users")
+	assert.NotContains(t, diff.Attributes, "permissions.0.dns.0.view_zones")
+}
@@ -50,32 +50,58 @@ func Provider() *schema.Provider {
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"ns1_zone":               dataSourceZone(),
-			"ns1_dnssec":             dataSourceDNSSEC(),
-			"ns1_record":             dataSourceRecord(),
-			"ns1_networks":           dataSourceNetworks(),
-			"ns1_monitoring_regions": dataSourceMonitoringRegions(),
-			"ns1_billing_usage":      billingUsageResource(),
+			"ns1_zone":                  dataSourceZone(),
+			"ns1_dnssec":                dataSourceDNSSEC(),
+			"ns1_record":                dataSourceRecord(),
+			"ns1_networks":              dataSourceNetworks(),
+			"ns1_monitoring_regions":    dataSourceMonitoringRegions(),
+			"ns1_billing_usage":         billingUsageResource(),
+			"ns1_monitoringjob_history": dataSourceMonitoringJobHistory(),
+			"ns1_notifylist":            dataSourceNotifyList(),
+			"ns1_monitoringjob":         dataSourceMonitoringJob(),
+			"ns1_datasource":            dataSourceDataSource(),
+			"ns1_datafeed":              dataSourceDataFeed(),
+			"ns1_permission_set":        dataSourcePermissionSet(),
+			"ns1_users":                 dataSourceUsers(),
+			"ns1_teams":                 dataSourceTeams(),
+			"ns1_apikeys":               dataSourceAPIKeys(),
+			"ns1_activity_log":          dataSourceActivityLog(),
+			"ns1_account_settings":      dataSourceAccountSettings(),
+			"ns1_redirects":             dataSourceRedirects(),
+			"ns1_redirect_certificate":  dataSourceRedirectCertificate(),
+			"ns1_dataset_report":        dataSourceDatasetReport(),
+			"ns1_applications":          dataSourceApplications(),
+			"ns1_pulsar_jobs":           dataSourcePulsarJobs(),
+			"ns1_pulsar_performance":    dataSourcePulsarPerformance(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"ns1_zone":                 resourceZone(),
-			"ns1_record":               recordResource(),
-			"ns1_datasource":           dataSourceResource(),
-			"ns1_datafeed":             dataFeedResource(),
-			"ns1_monitoringjob":        monitoringJobResource(),
-			"ns1_notifylist":           notifyListResource(),
-			"ns1_user":                 userResource(),
-			"ns1_apikey":               apikeyResource(),
-			"ns1_team":                 teamResource(),
-			"ns1_application":          resourceApplication(),
-			"ns1_pulsarjob":            pulsarJobResource(),
-			"ns1_tsigkey":              tsigKeyResource(),
-			"ns1_dnsview":              dnsView(),
-			"ns1_account_whitelist":    accountWhitelistResource(),
-			"ns1_dataset":              datasetResource(),
-			"ns1_redirect":             redirectConfigResource(),
-			"ns1_redirect_certificate": redirectCertificateResource(),
-			"ns1_alert":                alertResource(),
+			"ns1_zone":                    resourceZone(),
+			"ns1_record":                  recordResource(),
+			"ns1_datasource":              dataSourceResource(),
+			"ns1_datafeed":                dataFeedResource(),
+			"ns1_datafeed_publish":        dataFeedPublishResource(),
+			"ns1_monitoringjob":           monitoringJobResource(),
+			"ns1_notifylist":              notifyListResource(),
+			"ns1_notifylist_test":         notifyListTestResource(),
+			"ns1_user":                    userResource(),
+			"ns1_apikey":                  apikeyResource(),
+			"ns1_apikey_secret":           apikeySecretResource(),
+			"ns1_team":                    teamResource(),
+			"ns1_team_membership":         teamMembershipResource(),
+			"ns1_team_members":            teamMembersResource(),
+			"ns1_application":             resourceApplication(),
+			"ns1_pulsarjob":               pulsarJobResource(),
+			"ns1_tsigkey":                 tsigKeyResource(),
+			"ns1_dnsview":                 dnsView(),
+			"ns1_account_whitelist":       accountWhitelistResource(),
+			"ns1_account_whitelist_entry": accountWhitelistEntryResource(),
+			"ns1_account_settings":        accountSettingsResource(),
+			"ns1_account_security":        accountSecurityResource(),
+			"ns1_dataset":                 datasetResource(),
+			"ns1_redirect":                redirectConfigResource(),
+			"ns1_redirect_certificate":    redirectCertificateResource(),
+			"ns1_redirect_set":            redirectSetResource(),
+			"ns1_alert":                   alertResource(),
 		},
 		ConfigureFunc: ns1Configure,
 	}
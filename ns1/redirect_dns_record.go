@@ -0,0 +1,183 @@
+package ns1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+// redirectZoneCandidates returns the zones a redirect domain can be in,
+// longest first. The wildcard label of a wildcard domain can't be a zone.
+func redirectZoneCandidates(domain string) []string {
+	labels := strings.Split(normalizeDomain(domain), ".")
+	if labels[0] == "*" {
+		labels = labels[1:]
+	}
+	var out []string
+	for i := 0; i < len(labels)-1; i++ {
+		out = append(out, strings.Join(labels[i:], "."))
+	}
+	return out
+}
+
+// findRedirectZone returns the zone of the account the domain is in.
+func findRedirectZone(client *ns1.Client, domain string) (string, error) {
+	for _, zone := range redirectZoneCandidates(domain) {
+		_, resp, err := client.Zones.Get(zone, false)
+		if err == nil {
+			return zone, nil
+		}
+		if err != ns1.ErrZoneMissing {
+			return "", ConvertToNs1Error(resp, err)
+		}
+	}
+	return "", fmt.Errorf("no zone of %s is hosted in the account, its DNS record can't be managed", domain)
+}
+
+// redirectDNSRecordType is CNAME, or ALIAS at the apex of a zone where a
+// CNAME is not allowed.
+func redirectDNSRecordType(domain, zone string) string {
+	if normalizeDomain(domain) == normalizeDomain(zone) {
+		return "ALIAS"
+	}
+	return "CNAME"
+}
+
+// ensureRedirectDNSRecord creates the record pointing the domain at the
+// redirect service, or updates the answer of an existing one. An existing
+// record with another answer is only taken over when adopt is true, as it is
+// deleted with the redirect.
+func ensureRedirectDNSRecord(client *ns1.Client, domain, zone, target string, adopt bool) error {
+	rtype := redirectDNSRecordType(domain, zone)
+	r, resp, err := client.Records.Get(zone, domain, rtype)
+	if err != nil && err != ns1.ErrRecordMissing {
+		return ConvertToNs1Error(resp, err)
+	}
+	if err == ns1.ErrRecordMissing {
+		r = dns.NewRecord(zone, domain, rtype, nil, nil)
+		r.Answers = []*dns.Answer{dns.NewAnswer([]string{target})}
+		if resp, err := client.Records.Create(r); err != nil {
+			return fmt.Errorf("creating the %s record of redirect %s: %s", rtype, domain, ConvertToNs1Error(resp, err))
+		}
+		return nil
+	}
+
+	current := redirectDNSRecordTarget(r)
+	if normalizeDomain(current) == normalizeDomain(target) {
+		return nil
+	}
+	if !adopt {
+		return fmt.Errorf("the %s record of redirect %s already exists with answers other than %s, set adopt_dns_record to take it over",
+			rtype, domain, target)
+	}
+	r.Answers = []*dns.Answer{dns.NewAnswer([]string{target})}
+	if resp, err := client.Records.Update(r); err != nil {
+		return fmt.Errorf("updating the %s record of redirect %s: %s", rtype, domain, ConvertToNs1Error(resp, err))
+	}
+	return nil
+}
+
+// redirectDNSRecordTarget returns the target of a record with a single
+// answer, or "" when the record is not one a redirect would create.
+func redirectDNSRecordTarget(r *dns.Record) string {
+	if len(r.Answers) != 1 || len(r.Answers[0].Rdata) != 1 {
+		return ""
+	}
+	return r.Answers[0].Rdata[0]
+}
+
+// releaseRedirectDNSRecord deletes the record of the domain of a redirect,
+// unless another redirect is on the same domain.
+func releaseRedirectDNSRecord(client *ns1.Client, id, domain, zone string) error {
+	cfgs, resp, err := client.Redirects.List()
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	for _, cfg := range cfgs {
+		if cfg.ID != nil && *cfg.ID != id && strings.EqualFold(cfg.Domain, domain) {
+			log.Printf("[DEBUG] NS1 redirect (%s) is also on %s, keeping its DNS record", *cfg.ID, domain)
+			return nil
+		}
+	}
+
+	rtype := redirectDNSRecordType(domain, zone)
+	resp, err = client.Records.Delete(zone, domain, rtype)
+	if err != nil && err != ns1.ErrRecordMissing {
+		return fmt.Errorf("deleting the %s record of redirect %s: %s", rtype, domain, ConvertToNs1Error(resp, err))
+	}
+	return nil
+}
+
+// applyRedirectDNSRecord creates or releases the record of a redirect,
+// following manage_dns_record. The record of the previous domain is released
+// when the domain changes.
+func applyRedirectDNSRecord(client *ns1.Client, d *schema.ResourceData) error {
+	oldDomain, domain := d.GetChange("domain")
+	manage := d.Get("manage_dns_record").(bool)
+
+	owned := d.Get("dns_record_zone").(string)
+	if owned != "" && (!manage || d.HasChange("domain")) {
+		if err := releaseRedirectDNSRecord(client, d.Id(), oldDomain.(string), owned); err != nil {
+			return err
+		}
+		d.Set("dns_record_zone", "")
+		owned = ""
+	}
+	if !manage {
+		return nil
+	}
+
+	zone, err := findRedirectZone(client, domain.(string))
+	if err != nil {
+		return err
+	}
+	// The record is the redirect's own once it is in the state.
+	adopt := owned == zone || d.Get("adopt_dns_record").(bool)
+	if err := ensureRedirectDNSRecord(client, domain.(string), zone, d.Get("dns_record_target").(string), adopt); err != nil {
+		return err
+	}
+	d.Set("dns_record_zone", zone)
+	return nil
+}
+
+// readRedirectDNSRecord sets dns_record_target to the target of the record,
+// so that a record that was changed or deleted is fixed on the next apply.
+func readRedirectDNSRecord(client *ns1.Client, d *schema.ResourceData) error {
+	zone := d.Get("dns_record_zone").(string)
+	if !d.Get("manage_dns_record").(bool) || zone == "" {
+		return nil
+	}
+
+	domain := d.Get("domain").(string)
+	r, resp, err := client.Records.Get(zone, domain, redirectDNSRecordType(domain, zone))
+	if err != nil {
+		if err == ns1.ErrRecordMissing {
+			log.Printf("[DEBUG] NS1 DNS record of redirect (%s) not found", d.Id())
+			d.Set("dns_record_target", "")
+			return nil
+		}
+		return ConvertToNs1Error(resp, err)
+	}
+	d.Set("dns_record_target", redirectDNSRecordTarget(r))
+	return nil
+}
+
+// redirectDNSRecordTargetDiffSuppress ignores the case and trailing dot of
+// the target.
+func redirectDNSRecordTargetDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeDomain(old) == normalizeDomain(new)
+}
+
+func redirectConfigCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("manage_dns_record").(bool) && d.NewValueKnown("dns_record_target") && d.Get("dns_record_target").(string) == "" {
+		return errors.New("dns_record_target is required when manage_dns_record is true")
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package ns1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+func TestAccRedirectConfig_manageDNSRecord(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	zoneName := fmt.Sprintf("terraform-test-%s.io", rString)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccRedirectPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRedirectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRedirectManageDNSRecord(rString, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ns1_redirect.it", "dns_record_zone", zoneName),
+					testAccCheckRedirectDNSRecord(zoneName, "test."+zoneName, "CNAME", true),
+					testAccCheckRedirectDNSRecord(zoneName, zoneName, "ALIAS", true),
+				),
+			},
+			{
+				// the record is deleted when it is no longer managed
+				Config: testAccRedirectManageDNSRecord(rString, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ns1_redirect.it", "dns_record_zone", ""),
+					testAccCheckRedirectDNSRecord(zoneName, "test."+zoneName, "CNAME", false),
+					testAccCheckRedirectDNSRecord(zoneName, zoneName, "ALIAS", true),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`resource "ns1_redirect" "it" {
+  domain            = "test.missing-%s.io"
+  path              = "/from/path"
+  target            = "https://url.com/target/path"
+  manage_dns_record = true
+  dns_record_target = "redirect.example.net"
+}
+`, rString),
+				ExpectError: regexp.MustCompile(`is hosted in the account`),
+			},
+		},
+	})
+}
+
+func TestAccRedirectConfig_existingDNSRecord(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccRedirectPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRedirectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "ns1_zone" "test" {
+  zone = "terraform-test-%[1]s.io"
+}
+
+resource "ns1_record" "existing" {
+  zone   = ns1_zone.test.zone
+  domain = "test.${ns1_zone.test.zone}"
+  type   = "CNAME"
+  answers {
+    answer = "other.example.net"
+  }
+}
+
+resource "ns1_redirect" "it" {
+  domain            = ns1_record.existing.domain
+  path              = "/from/path"
+  target            = "https://url.com/target/path"
+  manage_dns_record = true
+  dns_record_target = "redirect.example.net"
+}
+`, rString),
+				ExpectError: regexp.MustCompile(`set adopt_dns_record to take it over`),
+			},
+		},
+	})
+}
+
+func TestRedirectZoneCandidates(t *testing.T) {
+	assert.Equal(t, []string{"a.b.example.com", "b.example.com", "example.com"}, redirectZoneCandidates("a.b.example.com"))
+	assert.Equal(t, []string{"example.com"}, redirectZoneCandidates("*.Example.com."))
+	assert.Equal(t, []string{"example.com"}, redirectZoneCandidates("example.com"))
+}
+
+func TestRedirectDNSRecordType(t *testing.T) {
+	assert.Equal(t, "ALIAS", redirectDNSRecordType("Example.com", "example.com."))
+	assert.Equal(t, "CNAME", redirectDNSRecordType("www.example.com", "example.com"))
+	assert.Equal(t, "CNAME", redirectDNSRecordType("*.example.com", "example.com"))
+}
+
+func TestRedirectConfigDNSRecordValidation(t *testing.T) {
+	r := redirectConfigResource()
+	config := map[string]interface{}{
+		"domain":            "www.example.com",
+		"path":              "/a",
+		"target":            "https://example.com",
+		"manage_dns_record": true,
+	}
+	state := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+
+	_, err := r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(config), nil)
+	assert.Error(t, err)
+
+	config["dns_record_target"] = "redirect.example.net"
+	_, err = r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(config), nil)
+	assert.NoError(t, err)
+}
+
+func testAccCheckRedirectDNSRecord(zone, domain, rtype string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*ns1.Client)
+		r, _, err := client.Records.Get(zone, domain, rtype)
+		if !exists {
+			if err != ns1.ErrRecordMissing {
+				return fmt.Errorf("%s record of %s still exists: %v", rtype, domain, err)
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if got := redirectDNSRecordTarget(r); got != "redirect.example.net" {
+			return fmt.Errorf("%s record of %s: expected target redirect.example.net, got %q", rtype, domain, got)
+		}
+		return nil
+	}
+}
+
+func testAccRedirectManageDNSRecord(rString string, manage bool) string {
+	return fmt.Sprintf(`
+resource "ns1_zone" "test" {
+  zone = "terraform-test-%[1]s.io"
+}
+
+resource "ns1_redirect" "it" {
+  domain            = "test.${ns1_zone.test.zone}"
+  path              = "/from/path"
+  target            = "https://url.com/target/path"
+  manage_dns_record = %[2]t
+  dns_record_target = "redirect.example.net"
+}
+
+resource "ns1_redirect" "apex" {
+  domain            = ns1_zone.test.zone
+  path              = "/from/path"
+  target            = "https://url.com/target/path"
+  manage_dns_record = true
+  dns_record_target = "redirect.example.net"
+}
+
+resource "ns1_redirect" "apex_other" {
+  domain = ns1_zone.test.zone
+  path   = "/other"
+  target = "https://url.com/other"
+}
+`, rString, manage)
+}
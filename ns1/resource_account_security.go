@@ -0,0 +1,227 @@
+package ns1
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+// The account-wide security settings have no service in ns1-go, so they are
+// read and written with raw requests.
+const (
+	accountTwoFactorPath = "account/settings/2fa"
+	accountSAMLPath      = "account/settings/saml"
+)
+
+// accountTwoFactor is the two-factor authentication policy of the account.
+type accountTwoFactor struct {
+	Enforced bool `json:"enforced"`
+}
+
+// accountSAML is the SAML identity provider of the account, and how the
+// attributes it asserts map users to teams.
+type accountSAML struct {
+	account.SAML
+	TeamMappings []accountSAMLTeamMapping `json:"team_mappings"`
+}
+
+// accountSAMLTeamMapping adds the users whose attribute has the value to
+// the team.
+type accountSAMLTeamMapping struct {
+	Attribute string `json:"attribute"`
+	Value     string `json:"value"`
+	TeamID    string `json:"team_id"`
+}
+
+func accountSecurityResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"customer_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"enforce_2fa": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"saml": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"sso": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+						"provider": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"metadata_url": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							ConflictsWith: []string{"saml.0.metadata_xml"},
+						},
+						"metadata_xml": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							ConflictsWith: []string{"saml.0.metadata_url"},
+							ValidateFunc:  validateSAMLMetadata,
+						},
+						"team_mapping": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"attribute": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"value": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"team_id": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Create:        AccountSecurityUpdate,
+		Read:          AccountSecurityRead,
+		Update:        AccountSecurityUpdate,
+		Delete:        AccountSecurityDelete,
+		Importer:      &schema.ResourceImporter{},
+		CustomizeDiff: samlCustomizeDiff,
+	}
+}
+
+func accountSAMLToSchema(s *accountSAML) []interface{} {
+	v := samlToSchema(s.SAML)
+	mappings := make([]interface{}, 0, len(s.TeamMappings))
+	for _, tm := range s.TeamMappings {
+		mappings = append(mappings, map[string]interface{}{
+			"attribute": tm.Attribute,
+			"value":     tm.Value,
+			"team_id":   tm.TeamID,
+		})
+	}
+	v[0].(map[string]interface{})["team_mapping"] = mappings
+	return v
+}
+
+// schemaToAccountSAML builds the SAML settings of the account, with its
+// team mappings sorted so that the body does not depend on set order.
+func schemaToAccountSAML(v []interface{}) *accountSAML {
+	s := &accountSAML{SAML: schemaToSAML(v), TeamMappings: []accountSAMLTeamMapping{}}
+	if len(v) == 0 || v[0] == nil {
+		return s
+	}
+	if set, ok := v[0].(map[string]interface{})["team_mapping"].(*schema.Set); ok {
+		for _, raw := range set.List() {
+			m := raw.(map[string]interface{})
+			s.TeamMappings = append(s.TeamMappings, accountSAMLTeamMapping{
+				Attribute: m["attribute"].(string),
+				Value:     m["value"].(string),
+				TeamID:    m["team_id"].(string),
+			})
+		}
+	}
+	sort.Slice(s.TeamMappings, func(i, j int) bool {
+		a, b := s.TeamMappings[i], s.TeamMappings[j]
+		if a.Attribute != b.Attribute {
+			return a.Attribute < b.Attribute
+		}
+		if a.Value != b.Value {
+			return a.Value < b.Value
+		}
+		return a.TeamID < b.TeamID
+	})
+	return s
+}
+
+// AccountSecurityRead reads the two-factor authentication policy and the
+// SAML identity provider of the account from ns1
+func AccountSecurityRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	s, resp, err := client.Settings.Get()
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+
+	var tfa accountTwoFactor
+	req, err := client.NewRequest("GET", accountTwoFactorPath, nil)
+	if err != nil {
+		return err
+	}
+	if resp, err := client.Do(req, &tfa); err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+
+	var saml accountSAML
+	req, err = client.NewRequest("GET", accountSAMLPath, nil)
+	if err != nil {
+		return err
+	}
+	if resp, err := client.Do(req, &saml); err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+
+	d.SetId(strconv.Itoa(s.CustomerID))
+	d.Set("customer_id", s.CustomerID)
+	d.Set("enforce_2fa", tfa.Enforced)
+	if err := d.Set("saml", accountSAMLToSchema(&saml)); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting saml for account %d, error: %#v", s.CustomerID, err)
+	}
+	return nil
+}
+
+// AccountSecurityUpdate updates the two-factor authentication policy and the
+// SAML identity provider of the account in ns1. Only the settings that are
+// configured are sent; the others are left as they are.
+func AccountSecurityUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+
+	if _, ok := d.GetOkExists("enforce_2fa"); ok {
+		body := &accountTwoFactor{Enforced: d.Get("enforce_2fa").(bool)}
+		req, err := client.NewRequest("POST", accountTwoFactorPath, body)
+		if err != nil {
+			return err
+		}
+		if resp, err := client.Do(req, nil); err != nil {
+			return ConvertToNs1Error(resp, err)
+		}
+	}
+
+	if v := d.Get("saml").([]interface{}); len(v) > 0 {
+		body := schemaToAccountSAML(v)
+		req, err := client.NewRequest("POST", accountSAMLPath, body)
+		if err != nil {
+			return err
+		}
+		if resp, err := client.Do(req, nil); err != nil {
+			return ConvertToNs1Error(resp, err)
+		}
+	}
+	return AccountSecurityRead(d, meta)
+}
+
+// AccountSecurityDelete removes the settings from the state. The settings of
+// the account are left as they are.
+func AccountSecurityDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,75 @@
+package ns1
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+func TestAccountSecurityUpdate(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.NoError(t, err)
+	defer mock.Shutdown()
+	client := ns1.NewClient(doer, ns1.SetAPIKey("apikey"))
+	client.Endpoint, _ = url.Parse("https://" + mock.Address + "/v1/")
+
+	yes := true
+	metadataURL, provider := "https://idp.example.com/metadata", "okta"
+	saml := &accountSAML{
+		SAML: account.SAML{SSO: true, IDP: account.IDP{
+			UseMetadataURL: &yes, MetadataURL: &metadataURL, Provider: &provider,
+		}},
+		TeamMappings: []accountSAMLTeamMapping{
+			{Attribute: "groups", Value: "dns-admins", TeamID: "admins"},
+			{Attribute: "groups", Value: "dns-readers", TeamID: "readers"},
+		},
+	}
+	tfa := &accountTwoFactor{Enforced: true}
+	require.NoError(t, mock.AddTestCase(http.MethodGet, "account/settings", http.StatusOK, nil, nil, "", &account.Setting{CustomerID: 1234}))
+	require.NoError(t, mock.AddTestCase(http.MethodPost, accountTwoFactorPath, http.StatusOK, nil, nil, tfa, tfa))
+	require.NoError(t, mock.AddTestCase(http.MethodGet, accountTwoFactorPath, http.StatusOK, nil, nil, "", tfa))
+	require.NoError(t, mock.AddTestCase(http.MethodPost, accountSAMLPath, http.StatusOK, nil, nil, saml, saml))
+	require.NoError(t, mock.AddTestCase(http.MethodGet, accountSAMLPath, http.StatusOK, nil, nil, "", saml))
+
+	d := schema.TestResourceDataRaw(t, accountSecurityResource().Schema, map[string]interface{}{
+		"enforce_2fa": true,
+		"saml": []interface{}{map[string]interface{}{
+			"sso":          true,
+			"provider":     "okta",
+			"metadata_url": "https://idp.example.com/metadata",
+			"team_mapping": []interface{}{
+				map[string]interface{}{"attribute": "groups", "value": "dns-readers", "team_id": "readers"},
+				map[string]interface{}{"attribute": "groups", "value": "dns-admins", "team_id": "admins"},
+			},
+		}},
+	})
+	require.NoError(t, AccountSecurityUpdate(d, client))
+
+	assert.Equal(t, "1234", d.Id())
+	assert.Equal(t, true, d.Get("enforce_2fa"))
+	assert.Equal(t, "https://idp.example.com/metadata", d.Get("saml.0.metadata_url"))
+	assert.Equal(t, 2, d.Get("saml.0.team_mapping").(*schema.Set).Len())
+}
+
+func TestSchemaToAccountSAML(t *testing.T) {
+	s := schemaToAccountSAML(nil)
+	assert.Equal(t, account.SAML{}, s.SAML)
+	assert.NotNil(t, s.TeamMappings)
+
+	in := &accountSAML{TeamMappings: []accountSAMLTeamMapping{
+		{Attribute: "groups", Value: "dns-admins", TeamID: "admins"},
+	}}
+	d := schema.TestResourceDataRaw(t, accountSecurityResource().Schema, map[string]interface{}{})
+	require.NoError(t, d.Set("saml", accountSAMLToSchema(in)))
+	out := schemaToAccountSAML(d.Get("saml").([]interface{}))
+	assert.Equal(t, in.TeamMappings, out.TeamMappings)
+	assert.False(t, out.SSO)
+}
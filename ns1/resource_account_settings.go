@@ -0,0 +1,186 @@
+package ns1
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+// accountSettingsFields maps the contact details of the account to their
+// keys in the API, and accountAddressFields those of its address.
+var (
+	accountSettingsFields = map[string]string{
+		"first_name": "firstname",
+		"last_name":  "lastname",
+		"company":    "company",
+		"phone":      "phone",
+		"email":      "email",
+	}
+	accountAddressFields = map[string]string{
+		"street":      "street",
+		"city":        "city",
+		"state":       "state",
+		"postal_code": "postalcode",
+		"country":     "country",
+	}
+)
+
+// accountSettingsSchema is the contact details of the account. Only the
+// fields that are configured are managed: the others are not read into the
+// state and keep their value in NS1.
+func accountSettingsSchema() map[string]*schema.Schema {
+	optional := func() *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		}
+	}
+	return map[string]*schema.Schema{
+		"customer_id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"first_name": optional(),
+		"last_name":  optional(),
+		"company":    optional(),
+		"phone":      optional(),
+		"email":      optional(),
+		"address": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"street":      optional(),
+					"city":        optional(),
+					"state":       optional(),
+					"postal_code": optional(),
+					"country":     optional(),
+				},
+			},
+		},
+	}
+}
+
+func accountSettingsResource() *schema.Resource {
+	return &schema.Resource{
+		Schema:   accountSettingsSchema(),
+		Create:   AccountSettingsUpdate,
+		Read:     AccountSettingsRead,
+		Update:   AccountSettingsUpdate,
+		Delete:   AccountSettingsDelete,
+		Importer: &schema.ResourceImporter{},
+	}
+}
+
+// accountSettingsToMap returns the contact details and address of the
+// account, keyed by their names in the schema.
+func accountSettingsToMap(s *account.Setting) (map[string]string, map[string]string) {
+	return map[string]string{
+		"first_name": s.FirstName,
+		"last_name":  s.LastName,
+		"company":    s.Company,
+		"phone":      s.Phone,
+		"email":      s.Email,
+	}, map[string]string{
+		"street":      s.Address.Street,
+		"city":        s.Address.City,
+		"state":       s.Address.State,
+		"postal_code": s.Address.Postal,
+		"country":     s.Address.Country,
+	}
+}
+
+// accountSettingsToResourceData sets the settings of the account. Unless all
+// is set, only the fields already in the state are set, so that fields that
+// are not configured are not diffed.
+func accountSettingsToResourceData(d *schema.ResourceData, s *account.Setting, all bool) error {
+	d.SetId(strconv.Itoa(s.CustomerID))
+	d.Set("customer_id", s.CustomerID)
+
+	fields, address := accountSettingsToMap(s)
+	for k, v := range fields {
+		if all || d.Get(k).(string) != "" {
+			d.Set(k, v)
+		}
+	}
+	a := make(map[string]interface{}, len(address))
+	for k, v := range address {
+		if all || d.Get("address.0."+k).(string) != "" {
+			a[k] = v
+		}
+	}
+	if len(a) == 0 && d.Get("address.#").(int) == 0 {
+		return nil
+	}
+	if err := d.Set("address", []interface{}{a}); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting address for account %d, error: %#v", s.CustomerID, err)
+	}
+	return nil
+}
+
+// accountSettingsBody returns the body of an update of the settings: the
+// current settings, with the fields that are configured or were removed
+// from the configuration. The fields of account.Setting are omitempty, so
+// a field can only be cleared by sending it empty in a body of its own.
+func accountSettingsBody(d *schema.ResourceData, current *account.Setting) map[string]interface{} {
+	fields, address := accountSettingsToMap(current)
+	body := make(map[string]interface{}, len(fields)+1)
+	for k, key := range accountSettingsFields {
+		v := fields[k]
+		if n := d.Get(k).(string); n != "" || d.HasChange(k) {
+			v = n
+		}
+		body[key] = v
+	}
+	a := make(map[string]interface{}, len(address))
+	for k, key := range accountAddressFields {
+		v := address[k]
+		if n := d.Get("address.0." + k).(string); n != "" || d.HasChange("address.0."+k) {
+			v = n
+		}
+		a[key] = v
+	}
+	body["address"] = a
+	return body
+}
+
+// AccountSettingsRead reads the contact details of the account from ns1
+func AccountSettingsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	s, resp, err := client.Settings.Get()
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	return accountSettingsToResourceData(d, s, false)
+}
+
+// AccountSettingsUpdate updates the contact details of the account in ns1.
+// The account always has settings, so creating the resource updates them.
+func AccountSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	s, resp, err := client.Settings.Get()
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+
+	req, err := client.NewRequest("POST", "account/settings", accountSettingsBody(d, s))
+	if err != nil {
+		return err
+	}
+	if resp, err := client.Do(req, nil); err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	return AccountSettingsRead(d, meta)
+}
+
+// AccountSettingsDelete removes the settings from the state. The settings of
+// the account are left as they are.
+func AccountSettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,125 @@
+package ns1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+	billingusage "gopkg.in/ns1/ns1-go.v2/rest/model/billingusage"
+)
+
+// The settings are those of the test account, so the test only checks that
+// applying the resource without arguments leaves them as they are.
+func TestAccAccountSettings_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "ns1_account_settings" "it" {}
+
+data "ns1_account_settings" "it" {
+  depends_on = [ns1_account_settings.it]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ns1_account_settings.it", "customer_id"),
+					resource.TestCheckResourceAttrPair("ns1_account_settings.it", "customer_id", "data.ns1_account_settings.it", "customer_id"),
+					resource.TestCheckResourceAttrSet("data.ns1_account_settings.it", "email"),
+					resource.TestCheckResourceAttrSet("data.ns1_account_settings.it", "plan_limits.0.records_limit"),
+				),
+			},
+			{
+				ResourceName:      "ns1_account_settings.it",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccountSettingsToResourceData(t *testing.T) {
+	in := &account.Setting{
+		CustomerID: 1234,
+		FirstName:  "Jane",
+		LastName:   "Doe",
+		Company:    "Example Inc.",
+		Phone:      "+1 555 0100",
+		Email:      "dns-admins@example.com",
+		Address: account.Address{
+			Street:  "1 Example Way",
+			City:    "New York",
+			State:   "NY",
+			Postal:  "10001",
+			Country: "US",
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, accountSettingsResource().Schema, map[string]interface{}{})
+	assert.NoError(t, accountSettingsToResourceData(d, in, true))
+	assert.Equal(t, "1234", d.Id())
+	assert.Equal(t, "10001", d.Get("address.0.postal_code"))
+
+	// Only the fields in the state are read by the resource.
+	d = schema.TestResourceDataRaw(t, accountSettingsResource().Schema, map[string]interface{}{
+		"company": "Old Inc.",
+		"address": []interface{}{map[string]interface{}{"city": "Boston"}},
+	})
+	assert.NoError(t, accountSettingsToResourceData(d, in, false))
+	assert.Equal(t, "Example Inc.", d.Get("company"))
+	assert.Equal(t, "", d.Get("email"))
+	assert.Equal(t, "New York", d.Get("address.0.city"))
+	assert.Equal(t, "", d.Get("address.0.street"))
+}
+
+func TestAccountSettingsBody(t *testing.T) {
+	current := &account.Setting{
+		FirstName: "Jane",
+		Company:   "Example Inc.",
+		Phone:     "+1 555 0100",
+		Address:   account.Address{City: "New York", Country: "US"},
+	}
+	r := accountSettingsResource()
+	state := r.Data(nil)
+	state.SetId("1234")
+	state.Set("phone", "+1 555 0100")
+	state.Set("address", []interface{}{map[string]interface{}{"city": "New York"}})
+
+	// phone and the city are removed, company is changed and the rest is
+	// left as it is.
+	config := map[string]interface{}{
+		"company": "Other Inc.",
+	}
+	diff, err := r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(config), nil)
+	assert.NoError(t, err)
+	d, err := schema.InternalMap(r.Schema).Data(state.State(), diff)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"firstname": "Jane",
+		"lastname":  "",
+		"company":   "Other Inc.",
+		"phone":     "",
+		"email":     "",
+		"address": map[string]interface{}{
+			"street":     "",
+			"city":       "",
+			"state":      "",
+			"postalcode": "",
+			"country":    "US",
+		},
+	}, accountSettingsBody(d, current))
+}
+
+func TestPlanLimitsToSchema(t *testing.T) {
+	out := planLimitsToSchema(&billingusage.Limits{QueriesLimit: 1000, NxdProtectionEnabled: true})
+	m := out[0].(map[string]interface{})
+	assert.Equal(t, 1000, m["queries_limit"])
+	assert.Equal(t, true, m["nxd_protection_enabled"])
+	assert.Equal(t, false, m["ddos_protection_enabled"])
+}
@@ -1,7 +1,11 @@
 package ns1
 
 import (
+	"fmt"
 	"log"
+	"net"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -15,11 +19,7 @@ func accountWhitelistResource() *schema.Resource {
 			Type:     schema.TypeString,
 			Required: true,
 		},
-		"values": {
-			Type:     schema.TypeList,
-			Elem:     &schema.Schema{Type: schema.TypeString},
-			Required: true,
-		},
+		"values": ipWhitelistSchema(true),
 	}
 
 	return &schema.Resource{
@@ -29,10 +29,95 @@ func accountWhitelistResource() *schema.Resource {
 		Update:        accountWhitelistUpdate,
 		Delete:        accountWhitelistDelete,
 		Importer:      &schema.ResourceImporter{},
-		SchemaVersion: 1,
+		SchemaVersion: 2,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    accountWhitelistResourceV1().CoreConfigSchema().ImpliedType(),
+				Upgrade: accountWhitelistStateUpgradeV1,
+				Version: 1,
+			},
+		},
+	}
+}
+
+// ipWhitelistSchema is a set of IP addresses and CIDR networks. Values are
+// kept in their normal form, so that 10.0.0.1 and 10.0.0.1/32 are the same.
+func ipWhitelistSchema(required bool) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Required: required,
+		Optional: !required,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validateIPWhitelistValue,
+			StateFunc: func(v interface{}) string {
+				return normalizeIPWhitelistValue(v.(string))
+			},
+		},
+		Set: hashIPWhitelistValue,
 	}
 }
 
+// normalizeIPWhitelistValue returns a single address without a prefix
+// length, and a network by its first address. Values that don't parse are
+// returned as they are.
+func normalizeIPWhitelistValue(v string) string {
+	v = strings.TrimSpace(v)
+	if !strings.Contains(v, "/") {
+		if ip := net.ParseIP(v); ip != nil {
+			return ip.String()
+		}
+		return v
+	}
+	ip, network, err := net.ParseCIDR(v)
+	if err != nil {
+		return v
+	}
+	if ones, bits := network.Mask.Size(); ones == bits {
+		return ip.String()
+	}
+	return network.String()
+}
+
+func hashIPWhitelistValue(v interface{}) int {
+	return schema.HashString(normalizeIPWhitelistValue(v.(string)))
+}
+
+func validateIPWhitelistValue(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if strings.Contains(v, "/") {
+		ip, network, err := net.ParseCIDR(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %q is not a valid IP address or CIDR network", key, v))
+		} else if !ip.Equal(network.IP) {
+			warns = append(warns, fmt.Errorf("%s: %q has host bits set and is the network %s", key, v, network).Error())
+		}
+		return warns, errs
+	}
+	if net.ParseIP(v) == nil {
+		errs = append(errs, fmt.Errorf("%s: %q is not a valid IP address or CIDR network", key, v))
+	}
+	return warns, errs
+}
+
+// schemaToIPWhitelist returns the normalized, sorted values of an IP
+// whitelist.
+func schemaToIPWhitelist(v interface{}) []string {
+	var raw []interface{}
+	switch v := v.(type) {
+	case *schema.Set:
+		raw = v.List()
+	case []interface{}:
+		raw = v
+	}
+	values := make([]string, 0, len(raw))
+	for _, ip := range raw {
+		values = unionStrings(values, []string{normalizeIPWhitelistValue(ip.(string))})
+	}
+	sort.Strings(values)
+	return values
+}
+
 func accountWhitelistToResourceData(d *schema.ResourceData, wl *account.IPWhitelist) error {
 	d.SetId(wl.ID)
 	d.Set("name", wl.Name)
@@ -43,15 +128,7 @@ func accountWhitelistToResourceData(d *schema.ResourceData, wl *account.IPWhitel
 func resourceDataToWhitelist(wl *account.IPWhitelist, d *schema.ResourceData) error {
 	wl.ID = d.Id()
 	wl.Name = d.Get("name").(string)
-
-	if v, ok := d.GetOk("values"); ok {
-		ipWhitelistRaw := v.([]interface{})
-		wl.Values = make([]string, len(ipWhitelistRaw))
-		for i, ip := range ipWhitelistRaw {
-			wl.Values[i] = ip.(string)
-		}
-	}
-
+	wl.Values = schemaToIPWhitelist(d.Get("values"))
 	return nil
 }
 
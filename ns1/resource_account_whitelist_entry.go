@@ -0,0 +1,132 @@
+package ns1
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+// accountWhitelistMu serializes the read-modify-write of global whitelists,
+// which several ns1_account_whitelist_entry can contribute to.
+var accountWhitelistMu sync.Mutex
+
+func accountWhitelistEntryResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"whitelist_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"values": ipWhitelistSchema(true),
+		},
+		Create: accountWhitelistEntryCreate,
+		Read:   accountWhitelistEntryRead,
+		Update: accountWhitelistEntryUpdate,
+		Delete: accountWhitelistEntryDelete,
+	}
+}
+
+// updateAccountWhitelist adds and removes values of a global whitelist,
+// leaving its other values as they are. Values added must not be in the
+// whitelist yet: an entry only claims values that nothing else declares, so
+// that the values it removes are its own.
+func updateAccountWhitelist(client *ns1.Client, id string, add, remove []string) error {
+	accountWhitelistMu.Lock()
+	defer accountWhitelistMu.Unlock()
+
+	wl, resp, err := client.GlobalIPWhitelist.Get(id)
+	if err != nil {
+		if err == ns1.ErrIPWhitelistMissing && len(add) == 0 {
+			log.Printf("[DEBUG] NS1 global whitelist (%s) not found", id)
+			return nil
+		}
+		return ConvertToNs1Error(resp, err)
+	}
+
+	values := schemaToIPWhitelist(toInterfaces(wl.Values))
+	if claimed := intersectStrings(add, values); len(claimed) > 0 {
+		return fmt.Errorf("%s already in global whitelist %s, and may be declared by its ns1_account_whitelist or another entry", strings.Join(claimed, ", "), id)
+	}
+	values = unionStrings(subtractStrings(values, remove), add)
+	wl.Values = values
+	if resp, err := client.GlobalIPWhitelist.Update(wl); err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	return nil
+}
+
+func toInterfaces(s []string) []interface{} {
+	out := make([]interface{}, len(s))
+	for i, v := range s {
+		out[i] = v
+	}
+	return out
+}
+
+func accountWhitelistEntryCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	id := d.Get("whitelist_id").(string)
+	if err := updateAccountWhitelist(client, id, schemaToIPWhitelist(d.Get("values")), nil); err != nil {
+		return err
+	}
+	d.SetId(fmt.Sprintf("%s/%s", id, d.Get("name").(string)))
+	return accountWhitelistEntryRead(d, meta)
+}
+
+// accountWhitelistEntryRead reads the values of the entry that are still in
+// the whitelist.
+func accountWhitelistEntryRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	id := d.Get("whitelist_id").(string)
+	wl, resp, err := client.GlobalIPWhitelist.Get(id)
+	if err != nil {
+		if err == ns1.ErrIPWhitelistMissing {
+			log.Printf("[DEBUG] NS1 global whitelist (%s) not found", id)
+			d.SetId("")
+			return nil
+		}
+		return ConvertToNs1Error(resp, err)
+	}
+
+	values := intersectStrings(schemaToIPWhitelist(d.Get("values")), schemaToIPWhitelist(toInterfaces(wl.Values)))
+	if len(values) == 0 {
+		log.Printf("[DEBUG] NS1 global whitelist (%s) has none of the values of %s", id, d.Id())
+		d.SetId("")
+		return nil
+	}
+	d.Set("values", values)
+	return nil
+}
+
+func accountWhitelistEntryUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	o, n := d.GetChange("values")
+	oldValues, newValues := schemaToIPWhitelist(o), schemaToIPWhitelist(n)
+	if err := updateAccountWhitelist(client, d.Get("whitelist_id").(string), subtractStrings(newValues, oldValues), subtractStrings(oldValues, newValues)); err != nil {
+		return err
+	}
+	return accountWhitelistEntryRead(d, meta)
+}
+
+// accountWhitelistEntryDelete removes the values of the entry from the
+// whitelist. No other entry or whitelist declares them, since an entry only
+// adds values that are not in the whitelist yet.
+func accountWhitelistEntryDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	if err := updateAccountWhitelist(client, d.Get("whitelist_id").(string), nil, schemaToIPWhitelist(d.Get("values"))); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
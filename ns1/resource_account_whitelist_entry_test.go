@@ -0,0 +1,101 @@
+package ns1
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+func TestAccAccountWhitelistEntry_basic(t *testing.T) {
+	var wl account.IPWhitelist
+	name := fmt.Sprintf("it-%s", acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccAccountWhitelistDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountWhitelistEntry(name, `"10.0.0.1/32", "10.1.0.0/16"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAccountWhitelistExists("ns1_account_whitelist.wl", &wl),
+					testAccCheckAccountWhitelistHas(&wl, []string{"10.0.0.1", "10.1.0.0/16", "10.2.0.0/16", "192.168.0.0/16"}),
+					resource.TestCheckResourceAttr("ns1_account_whitelist_entry.a", "values.#", "2"),
+					resource.TestCheckResourceAttr("ns1_account_whitelist_entry.b", "values.#", "1"),
+				),
+			},
+			{
+				Config: testAccAccountWhitelistEntry(name, `"10.0.0.1"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAccountWhitelistExists("ns1_account_whitelist.wl", &wl),
+					testAccCheckAccountWhitelistHas(&wl, []string{"10.0.0.1", "10.2.0.0/16", "192.168.0.0/16"}),
+					resource.TestCheckResourceAttr("ns1_account_whitelist_entry.a", "values.#", "1"),
+				),
+			},
+			// A value removed outside of Terraform is added back.
+			{
+				PreConfig:          testAccRemoveAccountWhitelistValue(&wl, "10.0.0.1"),
+				Config:             testAccAccountWhitelistEntry(name, `"10.0.0.1"`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// A value declared by the whitelist can't be claimed by an entry.
+			{
+				Config:      testAccAccountWhitelistEntry(name, `"10.0.0.1", "192.168.0.0/16"`),
+				ExpectError: regexp.MustCompile(`192.168.0.0/16 already in global whitelist`),
+			},
+		},
+	})
+}
+
+func testAccCheckAccountWhitelistHas(wl *account.IPWhitelist, expected []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		values := append([]string{}, wl.Values...)
+		sort.Strings(values)
+		if fmt.Sprint(values) != fmt.Sprint(expected) {
+			return fmt.Errorf("IPWhitelist.Values: got: %v want: %v", values, expected)
+		}
+		return nil
+	}
+}
+
+func testAccRemoveAccountWhitelistValue(wl *account.IPWhitelist, value string) func() {
+	return func() {
+		client := testAccProvider.Meta().(*ns1.Client)
+		if err := updateAccountWhitelist(client, wl.ID, nil, []string{value}); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func testAccAccountWhitelistEntry(name, values string) string {
+	return fmt.Sprintf(`resource "ns1_account_whitelist" "wl" {
+  name   = "%s"
+  values = ["192.168.0.0/16"]
+
+  lifecycle {
+    ignore_changes = [values]
+  }
+}
+
+resource "ns1_account_whitelist_entry" "a" {
+  whitelist_id = ns1_account_whitelist.wl.id
+  name         = "a"
+  values       = [%s]
+}
+
+resource "ns1_account_whitelist_entry" "b" {
+  whitelist_id = ns1_account_whitelist.wl.id
+  name         = "b"
+  values       = ["10.2.0.0/16"]
+}
+`, name, values)
+}
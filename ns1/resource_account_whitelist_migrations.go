@@ -0,0 +1,32 @@
+package ns1
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func accountWhitelistResourceV1() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"values": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Required: true,
+			},
+		},
+	}
+}
+
+// accountWhitelistStateUpgradeV1 moves values from a list to a set of
+// normalized values, dropping the duplicates the list allowed.
+func accountWhitelistStateUpgradeV1(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if values, ok := rawState["values"].([]interface{}); ok {
+		rawState["values"] = toInterfaces(schemaToIPWhitelist(values))
+	}
+	return rawState, nil
+}
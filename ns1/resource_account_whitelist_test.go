@@ -1,12 +1,15 @@
 package ns1
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
 )
@@ -86,6 +89,90 @@ func TestAccAccountWhitelist_updated(t *testing.T) {
 	})
 }
 
+func TestNormalizeIPWhitelistValue(t *testing.T) {
+	tests := map[string]string{
+		"10.0.0.1":        "10.0.0.1",
+		"10.0.0.1/32":     "10.0.0.1",
+		" 10.0.0.1 ":      "10.0.0.1",
+		"10.0.0.5/24":     "10.0.0.0/24",
+		"0.0.0.0/0":       "0.0.0.0/0",
+		"2001:DB8::1":     "2001:db8::1",
+		"2001:db8::1/128": "2001:db8::1",
+		"2001:db8::1/64":  "2001:db8::/64",
+		"not-an-ip":       "not-an-ip",
+	}
+	for in, expected := range tests {
+		assert.Equal(t, expected, normalizeIPWhitelistValue(in), in)
+	}
+}
+
+func TestValidateIPWhitelistValue(t *testing.T) {
+	tests := []struct {
+		in      string
+		expErrs int
+		expWarn int
+	}{
+		{"192.168.1.1", 0, 0},
+		{"192.168.1.0/24", 0, 0},
+		{"192.168.1.1/24", 0, 1},
+		{"2001:db8::/32", 0, 0},
+		{"192.168.1.300", 1, 0},
+		{"192.168.1.0/33", 1, 0},
+		{"example.com", 1, 0},
+	}
+	for _, tt := range tests {
+		warns, errs := validateIPWhitelistValue(tt.in, "values")
+		assert.Equal(t, tt.expErrs, len(errs), tt.in)
+		assert.Equal(t, tt.expWarn, len(warns), tt.in)
+	}
+}
+
+func TestIPWhitelistNoDiff(t *testing.T) {
+	r := accountWhitelistResource()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":   "wl",
+		"values": []interface{}{"10.0.0.1", "10.1.0.0/16"},
+	})
+	d.SetId("abc")
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":   "wl",
+		"values": []interface{}{"10.1.0.0/16", "10.0.0.1/32"},
+	})
+	diff, err := r.Diff(context.Background(), d.State(), config, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, diff)
+
+	wl := account.IPWhitelist{}
+	assert.NoError(t, resourceDataToWhitelist(&wl, d))
+	assert.Equal(t, []string{"10.0.0.1", "10.1.0.0/16"}, wl.Values)
+}
+
+func TestTeamIPWhitelistNoDiff(t *testing.T) {
+	r := teamResource()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name": "team",
+		"ip_whitelist": []interface{}{map[string]interface{}{
+			"name":   "office",
+			"values": []interface{}{"10.0.0.1", "10.1.0.0/16"},
+		}},
+	})
+	d.SetId("abc")
+	d.Set("verify_records_acl", false)
+	assert.NoError(t, recordsACLMatchesToResourceData(d, nil))
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "team",
+		"ip_whitelist": []interface{}{map[string]interface{}{
+			"name":   "office",
+			"values": []interface{}{"10.1.0.0/16", "10.0.0.1/32"},
+		}},
+	})
+	diff, err := r.Diff(context.Background(), d.State(), config, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, diff)
+}
+
 // Other stuff passed here
 
 func testAccCheckAccountWhitelistExists(n string, accountWhitelist *account.IPWhitelist) resource.TestCheckFunc {
@@ -182,3 +269,13 @@ func testAccAccountWhitelistUpdated(name string) string {
 			]
 	}`, name, name)
 }
+
+func TestAccountWhitelistStateUpgradeV1(t *testing.T) {
+	state, err := accountWhitelistStateUpgradeV1(context.Background(), map[string]interface{}{
+		"id":     "wl",
+		"name":   "wl",
+		"values": []interface{}{"10.0.0.5/24", "10.0.0.1/32", "10.0.0.0/24"},
+	}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"10.0.0.0/24", "10.0.0.1"}, state["values"])
+}
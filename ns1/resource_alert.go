@@ -1,14 +1,95 @@
 package ns1
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"log"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/alerting"
 )
 
+// alertKind describes what an alert of a type and subtype applies to, and
+// the settings of its data block.
+type alertKind struct {
+	// ZoneNames and RecordIDs allow zone_names and record_ids.
+	ZoneNames bool
+	RecordIDs bool
+	// Data lists the fields of the data block, and whether they are
+	// required.
+	Data map[string]bool
+}
+
+// alertDataSchema holds the fields of the data block of every alert kind.
+// A kind only sends and reads the fields it lists.
+var alertDataSchema = map[string]*schema.Schema{
+	"alert_at_percent": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntBetween(1, 100),
+	},
+}
+
+// alertKinds is keyed by type and subtype. New alert kinds are added here.
+// The client does not define the subtypes of record alerts.
+var alertKinds = func() map[string]map[string]alertKind {
+	kinds := map[string]map[string]alertKind{
+		"zone": {
+			"transfer_failed": {ZoneNames: true},
+		},
+		"record": {},
+		alerting.AlertTypeAccount: {
+			"saml_certificate_expired": {},
+		},
+		"redirects": {
+			"certificate_renewal_failed": {},
+		},
+	}
+	for subtype := range alerting.AllowedUsageSubtypes {
+		kinds[alerting.AlertTypeAccount][subtype] = alertKind{Data: map[string]bool{"alert_at_percent": true}}
+	}
+	return kinds
+}()
+
+// alertTypeKinds holds the kind of the subtypes of a type that are not in
+// alertKinds. Alerts of other types, e.g. monitoring alerts, can apply to
+// zones and records.
+var alertTypeKinds = map[string]alertKind{
+	"zone":                    {ZoneNames: true},
+	"record":                  {RecordIDs: true},
+	alerting.AlertTypeAccount: {},
+	"redirects":               {},
+}
+
+func alertTypes() []string {
+	types := make([]string, 0, len(alertKinds))
+	for t := range alertKinds {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func alertSubtypes(alertType string) []string {
+	var subtypes []string
+	for t, kinds := range alertKinds {
+		if alertType != "" && t != alertType {
+			continue
+		}
+		for subtype := range kinds {
+			if !containsString(subtypes, subtype) {
+				subtypes = append(subtypes, subtype)
+			}
+		}
+	}
+	sort.Strings(subtypes)
+	return subtypes
+}
+
 func alertResource() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -18,16 +99,14 @@ func alertResource() *schema.Resource {
 				Required: true,
 			},
 			"type": {
-				Type:     schema.TypeString,
-				Required: true,
-				// ValidateFunc:     validatePath,
-				// DiffSuppressFunc: caseSensitivityDiffSuppress,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: warnUnknownAlert(alertTypes()),
 			},
 			"subtype": {
-				Type:     schema.TypeString,
-				Required: true,
-				// ValidateFunc:     validateURL,
-				// DiffSuppressFunc: caseSensitivityDiffSuppress,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: warnUnknownAlert(alertSubtypes("")),
 			},
 			// Read-only
 			"id": {
@@ -77,22 +156,126 @@ func alertResource() *schema.Resource {
 			"data": {
 				Type:     schema.TypeSet,
 				Optional: true,
+				MaxItems: 1,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"alert_at_percent": {
-							Type:     schema.TypeInt,
-							Optional: true,
-						},
-					},
+					Schema: alertDataSchema,
 				},
 			},
 		},
-		Create:   AlertConfigCreate,
-		Read:     AlertConfigRead,
-		Update:   AlertConfigUpdate,
-		Delete:   AlertConfigDelete,
-		Importer: &schema.ResourceImporter{},
+		Create:        AlertConfigCreate,
+		Read:          AlertConfigRead,
+		Update:        AlertConfigUpdate,
+		Delete:        AlertConfigDelete,
+		Importer:      &schema.ResourceImporter{},
+		CustomizeDiff: alertCustomizeDiff,
+	}
+}
+
+// alertCustomizeDiff verifies the type and subtype combination, and that
+// the alert only has the zones, records and data of its kind.
+func alertCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("type") || !d.NewValueKnown("subtype") || !d.NewValueKnown("data") {
+		return nil
+	}
+	alertType, subtype := d.Get("type").(string), d.Get("subtype").(string)
+	kind, err := getAlertKind(alertType, subtype)
+	if err != nil {
+		return err
 	}
+
+	if !kind.ZoneNames && d.NewValueKnown("zone_names") && d.Get("zone_names").(*schema.Set).Len() > 0 {
+		return fmt.Errorf("zone_names can't be set on %s/%s alerts", alertType, subtype)
+	}
+	if !kind.RecordIDs && d.NewValueKnown("record_ids") && d.Get("record_ids").(*schema.Set).Len() > 0 {
+		return fmt.Errorf("record_ids can't be set on %s/%s alerts", alertType, subtype)
+	}
+
+	set := alertDataFields(d.Get("data").(*schema.Set).List())
+	for field := range set {
+		if _, ok := kind.Data[field]; !ok {
+			return fmt.Errorf("data.%s can't be set on %s/%s alerts", field, alertType, subtype)
+		}
+	}
+	for field, required := range kind.Data {
+		if _, ok := set[field]; required && !ok {
+			return fmt.Errorf("data.%s is required on %s/%s alerts", field, alertType, subtype)
+		}
+	}
+	return nil
+}
+
+// warnUnknownAlert warns about types and subtypes that are not in
+// alertKinds, as the settings of their alerts are not checked.
+func warnUnknownAlert(known []string) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (warns []string, errs []error) {
+		if s, ok := v.(string); ok && !containsString(known, s) {
+			warns = append(warns, fmt.Sprintf("%s %q is not known to the provider, the settings of the alert are not checked", k, s))
+		}
+		return warns, errs
+	}
+}
+
+// getAlertKind returns the kind of a type and subtype. A subtype that is not
+// in alertKinds has the targets of its type and any of the fields of the
+// data block, but a subtype of another type is an error.
+func getAlertKind(alertType, subtype string) (alertKind, error) {
+	if kind, ok := alertKinds[alertType][subtype]; ok {
+		return kind, nil
+	}
+	for _, t := range alertTypes() {
+		if _, ok := alertKinds[t][subtype]; ok {
+			return alertKind{}, fmt.Errorf("subtype %q is a subtype of %s alerts, not %s alerts", subtype, t, alertType)
+		}
+	}
+	kind, ok := alertTypeKinds[alertType]
+	if !ok {
+		kind = alertKind{ZoneNames: true, RecordIDs: true}
+	}
+	kind.Data = make(map[string]bool, len(alertDataSchema))
+	for field := range alertDataSchema {
+		kind.Data[field] = false
+	}
+	return kind, nil
+}
+
+// alertDataFields returns the fields of the data block that are set. A
+// field at its zero value is not set.
+func alertDataFields(data []interface{}) map[string]interface{} {
+	out := make(map[string]interface{})
+	for _, v := range data {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for k, v := range m {
+			if s, ok := alertDataSchema[k]; ok && v != s.ZeroValue() {
+				out[k] = v
+			}
+		}
+	}
+	return out
+}
+
+// alertDataToSchema reads the fields of the kind from the data of an alert.
+func alertDataToSchema(kind alertKind, raw json.RawMessage) (map[string]interface{}, error) {
+	params := map[string]interface{}{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, err
+		}
+	}
+	out := make(map[string]interface{}, len(kind.Data))
+	for field := range kind.Data {
+		v, ok := params[field]
+		if !ok {
+			continue
+		}
+		if n, ok := v.(float64); ok && alertDataSchema[field].Type == schema.TypeInt {
+			v = int(n)
+		}
+		out[field] = v
+	}
+	return out, nil
 }
 
 func alertToResourceData(d *schema.ResourceData, alert *alerting.Alert) error {
@@ -107,15 +290,25 @@ func alertToResourceData(d *schema.ResourceData, alert *alerting.Alert) error {
 	d.Set("notification_lists", alert.NotifierListIds)
 	d.Set("zone_names", alert.ZoneNames)
 	d.Set("record_ids", alert.RecordIds)
-	// a new resource can't be defined here apparently
-	// when trying to set data where it's not defined we get: set item just set doesn't exist
-	if _, ok := d.GetOk("data"); ok || string(alert.Data) != "{}" {
-		params := map[string]any{}
-		err := json.Unmarshal(alert.Data, &params)
-		if err != nil {
-			return err
-		}
+	// Fields of the data that are not fields of the kind, e.g. added by NS1,
+	// are left out.
+	var alertType, subtype string
+	if alert.Type != nil && alert.Subtype != nil {
+		alertType, subtype = *alert.Type, *alert.Subtype
+	}
+	kind, err := getAlertKind(alertType, subtype)
+	if err != nil {
+		log.Printf("[DEBUG] NS1 alert (%s): %s", d.Id(), err)
+		return nil
+	}
+	params, err := alertDataToSchema(kind, alert.Data)
+	if err != nil {
+		return err
+	}
+	if len(params) > 0 {
 		d.Set("data", []any{params})
+	} else {
+		d.Set("data", nil)
 	}
 	return nil
 }
@@ -181,25 +374,23 @@ func resourceDataToAlert(d *schema.ResourceData) (*alerting.Alert, error) {
 	} else {
 		alert.RecordIds = []string{}
 	}
-	if v, ok := d.GetOk("data"); ok {
-		params := map[string]any{}
-		if data, ok := v.(*schema.Set); ok {
-			for _, p := range data.List() {
-				if setting, ok := p.(map[string]any); ok {
-					for k, v := range setting {
-						params[k] = v
-					}
-				}
-			}
-		}
-		jsonData, err := json.Marshal(params)
-		if err != nil {
-			return nil, err
+	// Only the fields of the kind are sent, so that fields of other kinds
+	// are not sent at their zero value.
+	kind, err := getAlertKind(d.Get("type").(string), d.Get("subtype").(string))
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]any{}
+	for k, v := range alertDataFields(d.Get("data").(*schema.Set).List()) {
+		if _, ok := kind.Data[k]; ok {
+			params[k] = v
 		}
-		alert.Data = jsonData
-	} else {
-		alert.Data = []byte("{}")
 	}
+	jsonData, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	alert.Data = jsonData
 	return &alert, nil
 }
 
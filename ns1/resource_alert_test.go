@@ -1,6 +1,7 @@
 package ns1
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"reflect"
@@ -11,7 +12,10 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/alerting"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
@@ -122,7 +126,7 @@ func TestAccAlert_RecordUsage(t *testing.T) {
 					testAccCheckAlertData(&alert, `{"alert_at_percent":10}`),
 				)},
 			{Config: testRecordUsageAlert(alertName, 101),
-				ExpectError:        regexp.MustCompile("Please correct the following field: data.alert_at_percent"),
+				ExpectError:        regexp.MustCompile(`alert_at_percent to be in the range \(1 - 100\)`),
 				ExpectNonEmptyPlan: true,
 			},
 			{Config: testRecordUsageAlert(alertName, 50),
@@ -281,6 +285,126 @@ func TestAccAlert_ManualDelete(t *testing.T) {
 	})
 }
 
+func TestAlertValidation(t *testing.T) {
+	config := func(alertType, subtype string, extra map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{"name": "alert", "type": alertType, "subtype": subtype}
+		for k, v := range extra {
+			c[k] = v
+		}
+		return c
+	}
+	percent := func(p int) map[string]interface{} {
+		return map[string]interface{}{"data": []interface{}{map[string]interface{}{"alert_at_percent": p}}}
+	}
+
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+		errs   bool
+	}{
+		{"zone transfer", config("zone", "transfer_failed", map[string]interface{}{"zone_names": []interface{}{"example.com"}}), false},
+		{"saml", config("account", "saml_certificate_expired", nil), false},
+		{"redirect", config("redirects", "certificate_renewal_failed", nil), false},
+		{"query usage", config("account", "query_usage", percent(80)), false},
+		{"monitor usage", config("account", "monitor_usage", percent(1)), false},
+		{"record alert", config("record", "answer_changed", map[string]interface{}{"record_ids": []interface{}{"abc"}}), false},
+		{"zones on record alert", config("record", "answer_changed", map[string]interface{}{"zone_names": []interface{}{"example.com"}}), true},
+		{"unknown type", config("job", "down", map[string]interface{}{"record_ids": []interface{}{"abc"}}), false},
+		{"unknown subtype", config("zone", "transfer", map[string]interface{}{"zone_names": []interface{}{"example.com"}}), false},
+		{"data on unknown subtype", config("zone", "transfer", percent(80)), false},
+		{"data on unknown type", config("job", "down", percent(80)), false},
+		{"mismatched subtype", config("zone", "record_usage", percent(80)), true},
+		{"usage without data", config("account", "record_usage", nil), true},
+		{"usage out of range", config("account", "record_usage", percent(101)), true},
+		{"data on zone alert", config("zone", "transfer_failed", percent(80)), true},
+		{"zones on account alert", config("account", "saml_certificate_expired", map[string]interface{}{"zone_names": []interface{}{"example.com"}}), true},
+		{"records on zone alert", config("zone", "transfer_failed", map[string]interface{}{"record_ids": []interface{}{"abc"}}), true},
+	}
+	r := alertResource()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := terraform.NewResourceConfigRaw(tt.config)
+			if diags := r.Validate(rc); diags.HasError() {
+				assert.True(t, tt.errs, "validation errors: %v", diags)
+				return
+			}
+			_, err := r.Diff(context.Background(), nil, rc, nil)
+			assert.Equal(t, tt.errs, err != nil, "diff error: %v", err)
+		})
+	}
+}
+
+func TestAlertData(t *testing.T) {
+	r := alertResource()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":    "alert",
+		"type":    "zone",
+		"subtype": "transfer_failed",
+	})
+	alert, err := resourceDataToAlert(d)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(alert.Data))
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":    "alert",
+		"type":    "account",
+		"subtype": "record_usage",
+		"data":    []interface{}{map[string]interface{}{"alert_at_percent": 80}},
+	})
+	alert, err = resourceDataToAlert(d)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"alert_at_percent":80}`, string(alert.Data))
+
+	// fields that are not fields of the kind are not read
+	kind, err := getAlertKind("account", "record_usage")
+	require.NoError(t, err)
+	params, err := alertDataToSchema(kind, []byte(`{"alert_at_percent":80,"other":"x"}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"alert_at_percent": 80}, params)
+
+	kind, err = getAlertKind("zone", "transfer_failed")
+	require.NoError(t, err)
+	params, err = alertDataToSchema(kind, []byte(`{"alert_at_percent":80}`))
+	require.NoError(t, err)
+	assert.Empty(t, params)
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":    "alert",
+		"type":    "account",
+		"subtype": "new_usage",
+		"data":    []interface{}{map[string]interface{}{"alert_at_percent": 50}},
+	})
+	alert, err = resourceDataToAlert(d)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"alert_at_percent":50}`, string(alert.Data))
+
+	// unknown kinds keep every field of the data
+	kind, err = getAlertKind("account", "new_usage")
+	require.NoError(t, err)
+	params, err = alertDataToSchema(kind, []byte(`{"alert_at_percent":80,"other":"x"}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"alert_at_percent": 80}, params)
+
+	_, err = getAlertKind("zone", "record_usage")
+	assert.EqualError(t, err, `subtype "record_usage" is a subtype of account alerts, not zone alerts`)
+}
+
+func TestAlertUnknownWarnings(t *testing.T) {
+	r := alertResource()
+	diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "alert", "type": "job", "subtype": "down",
+	}))
+	require.False(t, diags.HasError())
+	require.Len(t, diags, 2)
+	assert.Contains(t, diags[0].Summary+diags[1].Summary, `type "job" is not known to the provider`)
+	assert.Contains(t, diags[0].Summary+diags[1].Summary, `subtype "down" is not known to the provider`)
+
+	diags = r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "alert", "type": "zone", "subtype": "transfer_failed",
+	}))
+	assert.Empty(t, diags)
+}
+
 func testAccSSOAlertBasic(alertName string) string {
 	return fmt.Sprintf(`resource "ns1_alert" "it" {
 	name               = "%s"
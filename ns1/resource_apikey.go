@@ -28,11 +28,7 @@ func apikeyResource() *schema.Resource {
 			Optional: true,
 			Elem:     &schema.Schema{Type: schema.TypeString},
 		},
-		"ip_whitelist": {
-			Type:     schema.TypeSet,
-			Optional: true,
-			Elem:     &schema.Schema{Type: schema.TypeString},
-		},
+		"ip_whitelist": ipWhitelistSchema(false),
 		"ip_whitelist_strict": {
 			Type:     schema.TypeBool,
 			Optional: true,
@@ -79,7 +75,8 @@ func apikeyResource() *schema.Resource {
 		},
 	}
 
-	s = addPermsSchema(s)
+	s = addMemberPermsSchema(s)
+	s = addRecordsACLSchema(s)
 
 	return &schema.Resource{
 		Schema:        s,
@@ -88,24 +85,35 @@ func apikeyResource() *schema.Resource {
 		Update:        ApikeyUpdate,
 		Delete:        ApikeyDelete,
 		Importer:      &schema.ResourceImporter{},
-		SchemaVersion: 1,
+		CustomizeDiff: recordsACLCustomizeDiff,
+		SchemaVersion: 2,
 		StateUpgraders: []schema.StateUpgrader{
 			{
 				Type:    apikeyResourceV0().CoreConfigSchema().ImpliedType(),
 				Upgrade: permissionInstanceStateUpgradeV0,
 				Version: 0,
 			},
+			{
+				Type:    apikeyResourceV1().CoreConfigSchema().ImpliedType(),
+				Upgrade: permissionInstanceStateUpgradeV1,
+				Version: 1,
+			},
 		},
 	}
 }
 
-func apikeyToResourceData(d *schema.ResourceData, k *account.APIKey) error {
+func apikeyToResourceData(d *schema.ResourceData, k *account.APIKey, client *ns1.Client) error {
 	d.SetId(k.ID)
 	d.Set("name", k.Name)
 	d.Set("teams", k.TeamIDs)
 	d.Set("ip_whitelist", k.IPWhitelist)
 	d.Set("ip_whitelist_strict", k.IPWhitelistStrict)
-	permissionsToResourceData(d, k.Permissions)
+	if err := memberPermissionsToResourceData(d, client, k.TeamIDs, k.Permissions); err != nil {
+		return err
+	}
+	if err := recordsACLMatchesToResourceData(d, client); err != nil {
+		return err
+	}
 
 	// keep the existing key in the state file when there's no key in the response
 	if k.Key != "" {
@@ -157,18 +165,8 @@ func resourceDataToApikey(k *account.APIKey, d *schema.ResourceData) error {
 	}
 	k.Permissions = resourceDataToPermissions(d)
 
-	if v, ok := d.GetOk("ip_whitelist"); ok {
-
-		ipWhitelistRaw := v.(*schema.Set)
-		k.IPWhitelist = make([]string, ipWhitelistRaw.Len())
-		for i, ip := range ipWhitelistRaw.List() {
-			k.IPWhitelist[i] = ip.(string)
-		}
-	} else {
-		// This still needs to be initialized to a zero value,
-		// otherwise it can't be removed.
-		k.IPWhitelist = make([]string, 0)
-	}
+	// This is never nil, otherwise the whitelist can't be removed.
+	k.IPWhitelist = schemaToIPWhitelist(d.Get("ip_whitelist"))
 
 	k.IPWhitelistStrict = d.Get("ip_whitelist_strict").(bool)
 
@@ -187,24 +185,13 @@ func ApikeyCreate(d *schema.ResourceData, meta interface{}) error {
 	if err := resourceDataToApikey(&k, d); err != nil {
 		return err
 	}
+	if err := verifyRecordsACL(d, client); err != nil {
+		return err
+	}
 	if resp, err := client.APIKeys.Create(&k); err != nil {
 		return ConvertToNs1Error(resp, err)
 	}
-
-	// If a key is assigned to at least one team, then it's permissions need to be refreshed
-	// because the current key permissions in Terraform state will be out of date.
-	if len(k.TeamIDs) > 0 {
-		updatedKey, resp, err := client.APIKeys.Get(k.ID)
-		if err != nil {
-			return ConvertToNs1Error(resp, err)
-		}
-		// Key attribute only avail on initial GET
-		updatedKey.Key = k.Key
-
-		return apikeyToResourceData(d, updatedKey)
-	}
-
-	return apikeyToResourceData(d, &k)
+	return apikeyToResourceData(d, &k, client)
 }
 
 // ApikeyRead reads API key from ns1
@@ -220,7 +207,7 @@ func ApikeyRead(d *schema.ResourceData, meta interface{}) error {
 
 		return ConvertToNs1Error(resp, err)
 	}
-	return apikeyToResourceData(d, k)
+	return apikeyToResourceData(d, k, client)
 }
 
 // ApikeyDelete deletes the given ns1 api key
@@ -242,20 +229,11 @@ func ApikeyUpdate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	if err := verifyRecordsACL(d, client); err != nil {
+		return err
+	}
 	if resp, err := client.APIKeys.Update(&k); err != nil {
 		return ConvertToNs1Error(resp, err)
 	}
-
-	// If a key's teams have changed then the permissions on the key need to be refreshed
-	// because the current key permissions in Terraform state will be out of date.
-	if d.HasChange("teams") {
-		updatedKey, resp, err := client.APIKeys.Get(d.Id())
-		if err != nil {
-			return ConvertToNs1Error(resp, err)
-		}
-
-		return apikeyToResourceData(d, updatedKey)
-	}
-
-	return apikeyToResourceData(d, &k)
+	return apikeyToResourceData(d, &k, client)
 }
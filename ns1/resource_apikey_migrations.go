@@ -29,3 +29,71 @@ func apikeyResourceV0() *schema.Resource {
 		Delete: ApikeyDelete,
 	}
 }
+
+func apikeyResourceV1() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"key": {
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+		"teams": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"ip_whitelist": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"ip_whitelist_strict": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"expiry_duration": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+		},
+		"secrets": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"expires_at": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"last_access": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"enabled": {
+						Type:     schema.TypeBool,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+
+	s = addPermsSchemaV1(s)
+
+	return &schema.Resource{
+		Schema: s,
+		Create: ApikeyCreate,
+		Read:   ApikeyRead,
+		Update: ApikeyUpdate,
+		Delete: ApikeyDelete,
+	}
+}
@@ -0,0 +1,241 @@
+package ns1
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+func apikeySecretResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"apikey_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"rotation_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"renew_before_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      7,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"overlap_hours": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      24,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"secret": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"expires_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"previous_secret_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"previous_disable_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		Create:        ApikeySecretCreate,
+		Read:          ApikeySecretRead,
+		Update:        ApikeySecretUpdate,
+		Delete:        ApikeySecretDelete,
+		CustomizeDiff: apikeySecretCustomizeDiff,
+	}
+}
+
+// secretRotationDue reports whether a secret expiring at expiresAt is within
+// days of expiring.
+func secretRotationDue(expiresAt string, days int, now time.Time) bool {
+	if days == 0 || expiresAt == "" {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		log.Printf("[WARN] could not parse secret expiry %q: %s", expiresAt, err)
+		return false
+	}
+	return now.Add(time.Duration(days) * 24 * time.Hour).After(t)
+}
+
+// previousSecretDue reports whether the overlap of the previous secret has
+// ended.
+func previousSecretDue(disableAt string, now time.Time) bool {
+	if disableAt == "" {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, disableAt)
+	if err != nil {
+		return true
+	}
+	return !now.Before(t)
+}
+
+// currentSecret returns the enabled secret of an API key that expires last.
+func currentSecret(k *account.APIKey) *account.APIKeySecret {
+	var current *account.APIKeySecret
+	for _, s := range k.Secrets {
+		if s.Enabled != nil && !*s.Enabled {
+			continue
+		}
+		if current == nil || s.ExpiresAt > current.ExpiresAt {
+			current = s
+		}
+	}
+	return current
+}
+
+func apikeySecretCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	if d.HasChange("rotation_trigger") ||
+		secretRotationDue(d.Get("expires_at").(string), d.Get("renew_before_days").(int), now) {
+		for _, k := range []string{"secret", "expires_at", "previous_secret_id", "previous_disable_at"} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if d.Get("previous_secret_id").(string) != "" && previousSecretDue(d.Get("previous_disable_at").(string), now) {
+		if err := d.SetNew("previous_secret_id", ""); err != nil {
+			return err
+		}
+		return d.SetNew("previous_disable_at", "")
+	}
+	return nil
+}
+
+// rotateApikeySecret renews the current secret, and disables the previous
+// secret now or sets when it is disabled.
+func rotateApikeySecret(d *schema.ResourceData, client *ns1.Client, currentID string) error {
+	// NS1 allows two enabled secrets per key, so a previous secret still in its
+	// overlap is disabled early.
+	if prev, _ := d.GetChange("previous_secret_id"); prev.(string) != "" {
+		log.Printf("[WARN] NS1 API key secret (%s) disabled before the end of its overlap", prev)
+		if err := disableApikeySecret(client, prev.(string)); err != nil {
+			return err
+		}
+	}
+
+	s, resp, err := client.APIKeys.RenewSecret(currentID)
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+
+	d.SetId(s.ID)
+	d.Set("secret", s.Key)
+	d.Set("expires_at", s.ExpiresAt)
+
+	overlap := time.Duration(d.Get("overlap_hours").(int)) * time.Hour
+	if overlap == 0 {
+		d.Set("previous_secret_id", "")
+		d.Set("previous_disable_at", "")
+		return disableApikeySecret(client, currentID)
+	}
+	d.Set("previous_secret_id", currentID)
+	d.Set("previous_disable_at", time.Now().UTC().Add(overlap).Format(time.RFC3339))
+	return nil
+}
+
+func disableApikeySecret(client *ns1.Client, id string) error {
+	enabled := false
+	resp, err := client.APIKeys.UpdateSecret(&account.APIKeySecret{ID: id, Enabled: &enabled})
+	if err != nil {
+		if err == ns1.ErrSecretMissing {
+			log.Printf("[DEBUG] NS1 API key secret (%s) not found", id)
+			return nil
+		}
+		return ConvertToNs1Error(resp, err)
+	}
+	return nil
+}
+
+// ApikeySecretCreate rotates the current secret of an API key
+func ApikeySecretCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	k, resp, err := client.APIKeys.Get(d.Get("apikey_id").(string))
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+
+	current := currentSecret(k)
+	if current == nil {
+		return fmt.Errorf("API key %s has no enabled secret, only keys with an expiry_duration have secrets", k.ID)
+	}
+	return rotateApikeySecret(d, client, current.ID)
+}
+
+// ApikeySecretRead reads the current secret of an API key
+func ApikeySecretRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	s, resp, err := client.APIKeys.GetSecret(d.Id())
+	if err != nil {
+		if err == ns1.ErrSecretMissing {
+			log.Printf("[DEBUG] NS1 API key secret (%s) not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return ConvertToNs1Error(resp, err)
+	}
+	if s.Enabled != nil && !*s.Enabled {
+		log.Printf("[DEBUG] NS1 API key secret (%s) is disabled", d.Id())
+		d.SetId("")
+		return nil
+	}
+	d.Set("expires_at", s.ExpiresAt)
+	return nil
+}
+
+// ApikeySecretUpdate rotates the secret when rotation_trigger changes or it
+// is about to expire, and disables the previous secret at the end of its
+// overlap
+func ApikeySecretUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	// The plan leaves the computed attributes of a rotation unknown, so the
+	// state values are used.
+	expiresAt, _ := d.GetChange("expires_at")
+	if d.HasChange("rotation_trigger") ||
+		secretRotationDue(expiresAt.(string), d.Get("renew_before_days").(int), time.Now().UTC()) {
+		return rotateApikeySecret(d, client, d.Id())
+	}
+
+	prev, _ := d.GetChange("previous_secret_id")
+	if prev.(string) != "" && d.Get("previous_secret_id").(string) == "" {
+		if err := disableApikeySecret(client, prev.(string)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApikeySecretDelete removes the secret from the state. The secret stays
+// enabled until it expires.
+func ApikeySecretDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
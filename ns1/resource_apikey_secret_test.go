@@ -0,0 +1,152 @@
+package ns1
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+func TestAccAPIKeySecret_basic(t *testing.T) {
+	var first string
+	name := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAPIKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAPIKeySecret(name, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ns1_apikey_secret.it", "secret"),
+					resource.TestCheckResourceAttrSet("ns1_apikey_secret.it", "expires_at"),
+					resource.TestCheckResourceAttrSet("ns1_apikey_secret.it", "previous_secret_id"),
+					testAccCheckAPIKeySecretID("ns1_apikey_secret.it", &first),
+				),
+			},
+			{
+				Config: testAccAPIKeySecret(name, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ns1_apikey_secret.it", "secret"),
+					resource.TestCheckResourceAttrPtr("ns1_apikey_secret.it", "previous_secret_id", &first),
+					testAccCheckAPIKeySecretEnabled("ns1_apikey_secret.it", "previous_secret_id", true),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAPIKeySecret_noOverlap(t *testing.T) {
+	name := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAPIKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAPIKeySecretNoOverlap(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ns1_apikey_secret.it", "secret"),
+					resource.TestCheckResourceAttr("ns1_apikey_secret.it", "previous_secret_id", ""),
+					testAccCheckAPIKeySecretEnabled("ns1_apikey_secret.it", "id", true),
+				),
+			},
+		},
+	})
+}
+
+func TestSecretRotationDue(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	assert.False(t, secretRotationDue("2024-06-20T00:00:00Z", 7, now))
+	assert.True(t, secretRotationDue("2024-06-05T00:00:00Z", 7, now))
+	assert.True(t, secretRotationDue("2024-05-30T00:00:00Z", 7, now))
+	// 0 days only rotates on a change of rotation_trigger.
+	assert.False(t, secretRotationDue("2024-05-30T00:00:00Z", 0, now))
+	assert.False(t, secretRotationDue("", 7, now))
+	assert.False(t, secretRotationDue("not a time", 7, now))
+}
+
+func TestPreviousSecretDue(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	assert.False(t, previousSecretDue("", now))
+	assert.False(t, previousSecretDue("2024-06-01T12:00:00Z", now))
+	assert.True(t, previousSecretDue("2024-06-01T00:00:00Z", now))
+	assert.True(t, previousSecretDue("2024-05-31T00:00:00Z", now))
+}
+
+func TestCurrentSecret(t *testing.T) {
+	enabled, disabled := true, false
+	k := &account.APIKey{Secrets: []*account.APIKeySecret{
+		{ID: "old", ExpiresAt: "2024-06-01T00:00:00Z", Enabled: &enabled},
+		{ID: "disabled", ExpiresAt: "2024-09-01T00:00:00Z", Enabled: &disabled},
+		{ID: "new", ExpiresAt: "2024-07-01T00:00:00Z", Enabled: &enabled},
+	}}
+	assert.Equal(t, "new", currentSecret(k).ID)
+	assert.Nil(t, currentSecret(&account.APIKey{}))
+}
+
+func testAccCheckAPIKeySecretID(n string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+func testAccCheckAPIKeySecretEnabled(n, attr string, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*ns1.Client)
+		secret, _, err := client.APIKeys.GetSecret(rs.Primary.Attributes[attr])
+		if err != nil {
+			return err
+		}
+		enabled := secret.Enabled == nil || *secret.Enabled
+		if enabled != expected {
+			return fmt.Errorf("secret %s: enabled: got: %t want: %t", secret.ID, enabled, expected)
+		}
+		return nil
+	}
+}
+
+func testAccAPIKeySecret(name, trigger string) string {
+	return fmt.Sprintf(`resource "ns1_apikey" "it" {
+  name            = "%s"
+  expiry_duration = "30d"
+}
+
+resource "ns1_apikey_secret" "it" {
+  apikey_id        = ns1_apikey.it.id
+  rotation_trigger = "%s"
+}
+`, name, trigger)
+}
+
+func testAccAPIKeySecretNoOverlap(name string) string {
+	return fmt.Sprintf(`resource "ns1_apikey" "it" {
+  name            = "%s"
+  expiry_duration = "30d"
+}
+
+resource "ns1_apikey_secret" "it" {
+  apikey_id     = ns1_apikey.it.id
+  overlap_hours = 0
+}
+`, name)
+}
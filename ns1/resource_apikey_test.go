@@ -165,6 +165,8 @@ func TestAccAPIKey_permissions(t *testing.T) {
 					testAccCheckAPIKeyExists("ns1_apikey.it", &apiKey),
 					testAccCheckAPIKeyName(&apiKey, name),
 					testAccCheckAPIKeyNotEmpty(&apiKey),
+					resource.TestCheckResourceAttr("ns1_apikey.it", "effective_permissions.0.account.0.manage_account_settings", "true"),
+					resource.TestCheckResourceAttr("ns1_apikey.it", "effective_permissions.0.account.0.manage_ip_whitelist", "true"),
 				),
 			},
 			{
@@ -173,21 +175,8 @@ func TestAccAPIKey_permissions(t *testing.T) {
 					testAccCheckAPIKeyExists("ns1_apikey.it", &apiKey),
 					testAccCheckAPIKeyName(&apiKey, name),
 					testAccCheckAPIKeyNotEmpty(&apiKey),
-					// The key should still have this permission, it would have inherited it from the team.
-					resource.TestCheckResourceAttr("ns1_apikey.it", "account_manage_account_settings", "true"),
-					resource.TestCheckResourceAttr("ns1_apikey.it", "account_manage_ip_whitelist", "true"),
-				),
-				ExpectNonEmptyPlan: true,
-			},
-			{
-				Config: testAccAPIKeyPermissionsNoTeam(rString),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAPIKeyExists("ns1_apikey.it", &apiKey),
-					testAccCheckAPIKeyName(&apiKey, name),
-					testAccCheckAPIKeyNotEmpty(&apiKey),
-					// But if an apply is ran again, the permission will be removed.
-					resource.TestCheckResourceAttr("ns1_apikey.it", "account_manage_account_settings", "false"),
-					resource.TestCheckResourceAttr("ns1_apikey.it", "account_manage_ip_whitelist", "false"),
+					testAccCheckPermissionAttr("ns1_apikey.it", "account.manage_account_settings", "false"),
+					testAccCheckPermissionAttr("ns1_apikey.it", "account.manage_ip_whitelist", "false"),
 				),
 			},
 			{
@@ -231,8 +220,15 @@ resource "ns1_apikey" "it" {
   ip_whitelist_strict = false
   ip_whitelist = []
 
-  dns_view_zones = false
-  account_manage_users = false
+  permissions {
+    dns {
+      view_zones = false
+    }
+
+    account {
+      manage_users = false
+    }
+  }
 }
 `, name)
 }
@@ -363,8 +359,13 @@ func testAccAPIKeyUpdated(apiKeyName string) string {
 func testAccAPIKeyPermissionsOnTeam(rString string) string {
 	return fmt.Sprintf(`resource "ns1_team" "t" {
   name = "terraform acc test team %s"
-  account_manage_account_settings = true
-  account_manage_ip_whitelist = true
+
+  permissions {
+    account {
+      manage_account_settings = true
+      manage_ip_whitelist     = true
+    }
+  }
 }
 
 resource "ns1_apikey" "it" {
@@ -473,8 +474,15 @@ func testAccAPIKeyWithExpiryDuration(apiKeyName, expiryDuration string) string {
   name = "%s"
   expiry_duration = "%s"
 
-  dns_view_zones = false
-  account_manage_users = false
+  permissions {
+    dns {
+      view_zones = false
+    }
+
+    account {
+      manage_users = false
+    }
+  }
 }
 `, apiKeyName, expiryDuration)
 }
@@ -555,8 +563,13 @@ func TestAccAPIKey_customExpiryDuration(t *testing.T) {
 func testAccAPIKeyPermissionsNoTeam(rString string) string {
 	return fmt.Sprintf(`resource "ns1_team" "t" {
   name = "terraform acc test team %s"
-  account_manage_account_settings = true
-  account_manage_ip_whitelist = true
+
+  permissions {
+    account {
+      manage_account_settings = true
+      manage_ip_whitelist     = true
+    }
+  }
 }
 
 resource "ns1_apikey" "it" {
@@ -7,29 +7,32 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
-	"strconv"
-
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
 )
 
 func dataFeedResource() *schema.Resource {
-	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
-			"source_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
-			},
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"config": {
-				Type:     schema.TypeMap,
-				Optional: true,
-			},
+	s := map[string]*schema.Schema{
+		"source_id": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
 		},
+		"config": {
+			Type:          schema.TypeMap,
+			Optional:      true,
+			ConflictsWith: feedConfigBlockNames(),
+		},
+	}
+	for name, block := range feedConfigBlocks() {
+		s[name] = block
+	}
+	return &schema.Resource{
+		Schema:   s,
 		Create:   DataFeedCreate,
 		Read:     DataFeedRead,
 		Update:   DataFeedUpdate,
@@ -38,43 +41,49 @@ func dataFeedResource() *schema.Resource {
 	}
 }
 
+// dataFeedConfigBlock returns the name of the typed feed config block set in
+// the configuration, or "" when config is used.
+func dataFeedConfigBlock(d *schema.ResourceData) string {
+	for _, name := range feedConfigBlockNames() {
+		if len(d.Get(name).([]interface{})) > 0 {
+			return name
+		}
+	}
+	return ""
+}
+
+// dataFeedResourceToResourceData sets the feed on the resource, in the typed
+// block when one is used and in config otherwise.
+func dataFeedResourceToResourceData(d *schema.ResourceData, f *data.Feed) error {
+	dataFeedToResourceData(d, f)
+	name := dataFeedConfigBlock(d)
+	if name == "" {
+		return nil
+	}
+	d.Set("config", nil)
+	block := feedConfigToBlock(sourceTypes[name].FeedConfig, f.Config)
+	if err := d.Set(name, []interface{}{block}); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting %s for: %s, error: %#v", name, f.Name, err)
+	}
+	return nil
+}
+
 func dataFeedToResourceData(d *schema.ResourceData, f *data.Feed) {
-	configAdapterOut(f)
 	d.SetId(f.ID)
 	d.Set("name", f.Name)
-	d.Set("config", f.Config)
+	d.Set("config", typedConfigOut(f.Config))
 }
 
-func resourceDataToDataFeed(d *schema.ResourceData) (feed *data.Feed, e error) {
-	config := d.Get("config").(map[string]interface{})
-	if config != nil {
-		if testId := config["test_id"]; testId != nil {
-			intTestId, err := strconv.Atoi(testId.(string))
-			if err != nil {
-				return &data.Feed{}, fmt.Errorf("could not convert test_id = %v as int: %w", testId, err)
-			}
-			config["test_id"] = intTestId
-		}
-		if checkId := config["check_id"]; checkId != nil {
-			intCheckId, err := strconv.Atoi(checkId.(string))
-			if err != nil {
-				return &data.Feed{}, fmt.Errorf("could not convert check_id = %v as int: %w", checkId, err)
-			}
-			config["check_id"] = intCheckId
-		}
-		if failOnWarning := config["fail_on_warning"]; failOnWarning != nil {
-			boolFailOnWarning, err := strconv.ParseBool(failOnWarning.(string))
-			if err != nil {
-				return &data.Feed{}, fmt.Errorf("could not convert fail_on_warning = %v as bool: %w", failOnWarning, err)
-			}
-			config["fail_on_warning"] = boolFailOnWarning
-		}
-		if failOnNoData := config["fail_on_no_data"]; failOnNoData != nil {
-			boolFailOnNoData, err := strconv.ParseBool(failOnNoData.(string))
-			if err != nil {
-				return &data.Feed{}, fmt.Errorf("could not convert fail_on_no_data = %v as bool: %w", failOnNoData, err)
-			}
-			config["fail_on_no_data"] = boolFailOnNoData
+func resourceDataToDataFeed(d *schema.ResourceData) (*data.Feed, error) {
+	var config map[string]interface{}
+	if name := dataFeedConfigBlock(d); name != "" {
+		block := d.Get(name).([]interface{})[0].(map[string]interface{})
+		config = feedConfigFromBlock(sourceTypes[name].FeedConfig, block)
+	} else {
+		var err error
+		config, err = typedConfigIn(feedConfigKinds(), d.Get("config").(map[string]interface{}))
+		if err != nil {
+			return &data.Feed{}, err
 		}
 	}
 
@@ -85,9 +94,36 @@ func resourceDataToDataFeed(d *schema.ResourceData) (feed *data.Feed, e error) {
 	}, nil
 }
 
+// checkDataFeedSource checks the feed config against the type of the feed's
+// source. It runs at apply, since the source may not exist at plan time.
+func checkDataFeedSource(client *ns1.Client, d *schema.ResourceData) error {
+	s, resp, err := client.DataSources.Get(d.Get("source_id").(string))
+	if err != nil {
+		if strings.Contains(err.Error(), "source not found") {
+			return fmt.Errorf("datasource %s not found", d.Get("source_id"))
+		}
+		return ConvertToNs1Error(resp, err)
+	}
+	if name := dataFeedConfigBlock(d); name != "" {
+		if name != s.Type {
+			return fmt.Errorf("%s block set for a feed of datasource %s, which has source type %s", name, s.ID, s.Type)
+		}
+		return nil
+	}
+	st, ok := sourceTypes[s.Type]
+	if !ok {
+		log.Printf("[WARN] datasource %s has unknown source type %q, not validating datafeed config", s.ID, s.Type)
+		return nil
+	}
+	return checkTypedConfig(st.FeedConfig, d.Get("config").(map[string]interface{}))
+}
+
 // DataFeedCreate creates an ns1 datafeed
 func DataFeedCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
+	if err := checkDataFeedSource(client, d); err != nil {
+		return err
+	}
 	f, err := resourceDataToDataFeed(d)
 	if err != nil {
 		return err
@@ -95,8 +131,7 @@ func DataFeedCreate(d *schema.ResourceData, meta interface{}) error {
 	if resp, err := client.DataFeeds.Create(d.Get("source_id").(string), f); err != nil {
 		return ConvertToNs1Error(resp, err)
 	}
-	dataFeedToResourceData(d, f)
-	return nil
+	return dataFeedResourceToResourceData(d, f)
 }
 
 // DataFeedRead reads the datafeed for the given ID from ns1
@@ -113,8 +148,7 @@ func DataFeedRead(d *schema.ResourceData, meta interface{}) error {
 
 		return ConvertToNs1Error(resp, err)
 	}
-	dataFeedToResourceData(d, f)
-	return nil
+	return dataFeedResourceToResourceData(d, f)
 }
 
 // DataFeedDelete delets the given datafeed from ns1
@@ -128,6 +162,9 @@ func DataFeedDelete(d *schema.ResourceData, meta interface{}) error {
 // DataFeedUpdate updates the given datafeed with modified parameters
 func DataFeedUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
+	if err := checkDataFeedSource(client, d); err != nil {
+		return err
+	}
 	f, err := resourceDataToDataFeed(d)
 	if err != nil {
 		return err
@@ -136,32 +173,7 @@ func DataFeedUpdate(d *schema.ResourceData, meta interface{}) error {
 	if resp, err := client.DataFeeds.Update(d.Get("source_id").(string), f); err != nil {
 		return ConvertToNs1Error(resp, err)
 	}
-	dataFeedToResourceData(d, f)
-	return nil
-}
-
-// configAdapterOut back the original configuration types
-func configAdapterOut(f *data.Feed) {
-	config := f.Config
-	if config != nil {
-		if testId := config["test_id"]; testId != nil {
-			strTestId := strconv.Itoa(int(testId.(float64)))
-			config["test_id"] = strTestId
-		}
-		if checkId := config["check_id"]; checkId != nil {
-			strCheckId := strconv.Itoa(int(checkId.(float64)))
-			config["check_id"] = strCheckId
-		}
-		if failOnWarning := config["fail_on_warning"]; failOnWarning != nil {
-			strFailOnWarning := strconv.FormatBool(failOnWarning.(bool))
-			config["fail_on_warning"] = strFailOnWarning
-		}
-		if failOnNoData := config["fail_on_no_data"]; failOnNoData != nil {
-			strFailOnNoData := strconv.FormatBool(failOnNoData.(bool))
-			config["fail_on_no_data"] = strFailOnNoData
-		}
-		f.Config = config
-	}
+	return dataFeedResourceToResourceData(d, f)
 }
 
 func dataFeedStateFunc(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -0,0 +1,285 @@
+package ns1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+)
+
+func dataFeedPublishResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"source_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"feed_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"data": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"restore_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"previous_data": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Create: DataFeedPublishCreate,
+		Read:   DataFeedPublishRead,
+		Update: DataFeedPublishUpdate,
+		Delete: DataFeedPublishDelete,
+	}
+}
+
+// publishedData returns the configured values, replaced by the feed's current
+// values where the two differ, so that values published elsewhere show as
+// drift. Values are compared in the form NS1 stores them, so that "true" and
+// "1" are the same value of up. Keys the feed does not report are kept.
+func publishedData(configured map[string]interface{}, current *data.Meta) map[string]interface{} {
+	want := data.MetaFromMap(configured).StringMap()
+	have := current.StringMap()
+
+	out := make(map[string]interface{}, len(configured))
+	for k, v := range configured {
+		cur, ok := have[k]
+		if !ok || cur == want[k] {
+			out[k] = v
+		} else {
+			out[k] = cur
+		}
+	}
+	return out
+}
+
+// dataFeedMetaKeys are the keys of data.Meta that can be published.
+var dataFeedMetaKeys = func() []string {
+	var keys []string
+	t := reflect.TypeOf(data.Meta{})
+	for i := 0; i < t.NumField(); i++ {
+		if name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}()
+
+// dataFeedPayload returns the values to publish to a feed, with the keys in
+// clear set to null so that NS1 removes them from the feed. Keys that are not
+// metadata would be dropped without being published, so they are an error.
+func dataFeedPayload(values map[string]interface{}, clear []string) (map[string]interface{}, error) {
+	var unknown []string
+	for k := range values {
+		if !containsString(dataFeedMetaKeys, k) {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("datafeed data has unknown keys %s, expecting keys of NS1 metadata such as up, connections or loadavg",
+			strings.Join(unknown, ", "))
+	}
+
+	meta := data.MetaFromMap(values)
+	if errs := meta.Validate(); len(errs) > 0 {
+		return nil, errJoin(append([]error{errors.New("found error/s in datafeed data")}, errs...), ",")
+	}
+
+	b, err := json.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	payload := make(map[string]interface{})
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return nil, err
+	}
+	for _, k := range clear {
+		if _, ok := payload[k]; !ok {
+			payload[k] = nil
+		}
+	}
+	return payload, nil
+}
+
+// publishDataFeed publishes values to a feed, addressing it by its label, and
+// removes the keys in clear from it.
+func publishDataFeed(client *ns1.Client, sourceID string, f *data.Feed, values map[string]interface{}, clear []string) error {
+	label, ok := f.Config["label"].(string)
+	if !ok || label == "" {
+		return fmt.Errorf("datafeed %s has no label, only feeds of nsone_v1 datasources can be published to", f.ID)
+	}
+
+	payload, err := dataFeedPayload(values, clear)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.DataSources.Publish(sourceID, map[string]interface{}{label: payload})
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	return nil
+}
+
+// dataFeedRestore returns the values to publish and the keys to clear to undo
+// the publishing of keys: keys with a previous value get it back, the others
+// had no value and are cleared.
+func dataFeedRestore(keys []string, previous map[string]interface{}) (map[string]interface{}, []string) {
+	values := make(map[string]interface{})
+	clear := make([]string, 0)
+	for _, k := range keys {
+		if v, ok := previous[k]; ok {
+			values[k] = v
+		} else {
+			clear = append(clear, k)
+		}
+	}
+	sort.Strings(clear)
+	return values, clear
+}
+
+// dataFeedPublish records the feed's current value of any key not published
+// before, then publishes data. Keys removed from data are restored or cleared
+// if restore_on_destroy is set, and are no longer recorded.
+func dataFeedPublish(d *schema.ResourceData, client *ns1.Client) error {
+	sourceID := d.Get("source_id").(string)
+	f, resp, err := client.DataFeeds.Get(sourceID, d.Get("feed_id").(string))
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+
+	o, n := d.GetChange("data")
+	values := n.(map[string]interface{})
+	previous := d.Get("previous_data").(map[string]interface{})
+	current := f.Data.StringMap()
+	for k := range values {
+		if _, ok := previous[k]; ok {
+			continue
+		}
+		if v, ok := current[k]; ok {
+			previous[k] = v
+		}
+	}
+
+	removed := make([]string, 0)
+	for k := range o.(map[string]interface{}) {
+		if _, ok := values[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	publish := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		publish[k] = v
+	}
+	var clear []string
+	if d.Get("restore_on_destroy").(bool) {
+		var restored map[string]interface{}
+		restored, clear = dataFeedRestore(removed, previous)
+		for k, v := range restored {
+			publish[k] = v
+		}
+	}
+	for _, k := range removed {
+		delete(previous, k)
+	}
+
+	if err := publishDataFeed(client, sourceID, f, publish, clear); err != nil {
+		return err
+	}
+	d.SetId(f.ID)
+	d.Set("previous_data", previous)
+	return nil
+}
+
+// DataFeedPublishCreate publishes data to a datafeed
+func DataFeedPublishCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	// Published values take a moment to show on the feed, so state is not
+	// read back here.
+	return dataFeedPublish(d, client)
+}
+
+// DataFeedPublishRead reads the values currently published to the datafeed
+func DataFeedPublishRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	f, resp, err := client.DataFeeds.Get(d.Get("source_id").(string), d.Id())
+	if err != nil {
+		// No custom error type is currently defined in the SDK for a data feed.
+		if strings.Contains(err.Error(), "not found") {
+			log.Printf("[DEBUG] NS1 data feed (%s) not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return ConvertToNs1Error(resp, err)
+	}
+	d.Set("feed_id", f.ID)
+	d.Set("data", publishedData(d.Get("data").(map[string]interface{}), &f.Data))
+	return nil
+}
+
+// DataFeedPublishUpdate publishes changed data to the datafeed
+func DataFeedPublishUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	if d.HasChange("data") {
+		return dataFeedPublish(d, client)
+	}
+	return nil
+}
+
+// DataFeedPublishDelete publishes the values the datafeed had before it was
+// first published to, and clears the keys it had no value for, if
+// restore_on_destroy is set
+func DataFeedPublishDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	if !d.Get("restore_on_destroy").(bool) {
+		d.SetId("")
+		return nil
+	}
+
+	keys := make([]string, 0)
+	for k := range d.Get("data").(map[string]interface{}) {
+		keys = append(keys, k)
+	}
+	values, clear := dataFeedRestore(keys, d.Get("previous_data").(map[string]interface{}))
+
+	sourceID := d.Get("source_id").(string)
+	f, resp, err := client.DataFeeds.Get(sourceID, d.Id())
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			log.Printf("[DEBUG] NS1 data feed (%s) not found, nothing to restore", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return ConvertToNs1Error(resp, err)
+	}
+
+	if err := publishDataFeed(client, sourceID, f, values, clear); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,163 @@
+package ns1
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+)
+
+func TestAccDataFeedPublish_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataFeedDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataFeedPublishBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ns1_datafeed_publish.maintenance", "data.up", "false"),
+					resource.TestCheckResourceAttr("ns1_datafeed_publish.maintenance", "restore_on_destroy", "true"),
+					testAccCheckDataFeedPublished("ns1_datafeed_publish.maintenance", "up", "0"),
+				),
+			},
+			{
+				Config: testAccDataFeedPublishUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ns1_datafeed_publish.maintenance", "data.up", "true"),
+					resource.TestCheckResourceAttr("ns1_datafeed_publish.maintenance", "data.connections", "10"),
+					testAccCheckDataFeedPublished("ns1_datafeed_publish.maintenance", "connections", "10"),
+				),
+			},
+			{
+				// connections had no value before, so it is cleared.
+				Config: testAccDataFeedPublishBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("ns1_datafeed_publish.maintenance", "data.connections"),
+					testAccCheckDataFeedPublished("ns1_datafeed_publish.maintenance", "up", "0"),
+					testAccCheckDataFeedPublished("ns1_datafeed_publish.maintenance", "connections", ""),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckDataFeedPublished waits for a published value to show on the feed.
+func testAccCheckDataFeedPublished(n, key, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*ns1.Client)
+		var got string
+		for i := 0; i < 10; i++ {
+			f, _, err := client.DataFeeds.Get(rs.Primary.Attributes["source_id"], rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+			got, _ = f.Data.StringMap()[key].(string)
+			if got == expected {
+				return nil
+			}
+			time.Sleep(time.Second)
+		}
+		return fmt.Errorf("datafeed data[%s]: got: %#v want: %#v", key, got, expected)
+	}
+}
+
+func TestPublishedData(t *testing.T) {
+	configured := map[string]interface{}{
+		"up":          "true",
+		"connections": "10",
+		"loadavg":     "1.5",
+	}
+
+	// Values published as configured are kept as written.
+	current := data.MetaFromMap(map[string]interface{}{"up": "1", "connections": "10", "loadavg": "1.5"})
+	assert.Equal(t, configured, publishedData(configured, current))
+
+	// Values published elsewhere show as drift, missing ones are kept.
+	current = data.MetaFromMap(map[string]interface{}{"up": "0", "connections": "10"})
+	assert.Equal(t, map[string]interface{}{
+		"up":          "0",
+		"connections": "10",
+		"loadavg":     "1.5",
+	}, publishedData(configured, current))
+}
+
+func TestDataFeedPayload(t *testing.T) {
+	payload, err := dataFeedPayload(map[string]interface{}{"up": "false", "connections": "10"}, []string{"loadavg", "up"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"up":          false,
+		"connections": float64(10),
+		"loadavg":     nil,
+	}, payload)
+
+	_, err = dataFeedPayload(map[string]interface{}{"up": "maybe"}, nil)
+	assert.Error(t, err)
+
+	_, err = dataFeedPayload(map[string]interface{}{"upp": "1", "us_state": "NY", "conections": "1"}, nil)
+	assert.EqualError(t, err, "datafeed data has unknown keys conections, upp, expecting keys of NS1 metadata such as up, connections or loadavg")
+}
+
+func TestDataFeedRestore(t *testing.T) {
+	values, clear := dataFeedRestore([]string{"up", "loadavg", "connections"}, map[string]interface{}{"up": "1", "priority": "2"})
+	assert.Equal(t, map[string]interface{}{"up": "1"}, values)
+	assert.Equal(t, []string{"connections", "loadavg"}, clear)
+}
+
+const testAccDataFeedPublishBasic = `
+resource "ns1_datasource" "api" {
+  name = "terraform test"
+  sourcetype = "nsone_v1"
+}
+
+resource "ns1_datafeed" "foobar" {
+  name = "terraform test"
+  source_id = ns1_datasource.api.id
+  config = {
+    label = "exampledc2"
+  }
+}
+
+resource "ns1_datafeed_publish" "maintenance" {
+  source_id = ns1_datasource.api.id
+  feed_id = ns1_datafeed.foobar.id
+  restore_on_destroy = true
+  data = {
+    up = "false"
+  }
+}`
+
+const testAccDataFeedPublishUpdated = `
+resource "ns1_datasource" "api" {
+  name = "terraform test"
+  sourcetype = "nsone_v1"
+}
+
+resource "ns1_datafeed" "foobar" {
+  name = "terraform test"
+  source_id = ns1_datasource.api.id
+  config = {
+    label = "exampledc2"
+  }
+}
+
+resource "ns1_datafeed_publish" "maintenance" {
+  source_id = ns1_datasource.api.id
+  feed_id = ns1_datafeed.foobar.id
+  restore_on_destroy = true
+  data = {
+    up = "true"
+    connections = "10"
+  }
+}`
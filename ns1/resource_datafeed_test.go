@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -51,6 +52,26 @@ func TestThousandeyes_Basic(t *testing.T) {
 	})
 }
 
+func TestThousandeyes_typedBlock(t *testing.T) {
+	var dataFeed data.Feed
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataFeedDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testThousandeyesSource + testThousandeyesTypedBlock,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataFeedExists("ns1_datafeed.uswest_feed", "ns1_datasource.api", &dataFeed, t),
+					testThousandeyesConfig(&dataFeed, "test_id", 123),
+					resource.TestCheckResourceAttr("ns1_datafeed.uswest_feed", "thousandeyes.0.test_id", "123"),
+					resource.TestCheckResourceAttr("ns1_datafeed.uswest_feed", "config.%", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestWebcheck_Basic(t *testing.T) {
 	var dataFeed data.Feed
 	resource.Test(t, resource.TestCase{
@@ -89,6 +110,31 @@ func TestDatadog_Basic(t *testing.T) {
 	})
 }
 
+func TestAccDataFeed_invalidConfig(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataFeedDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testThousandeyesSource,
+			},
+			{
+				Config:      testThousandeyesSource + testThousandeyesMissingTestID,
+				ExpectError: regexp.MustCompile(`config: "test_id" is required`),
+			},
+			{
+				Config:      testThousandeyesSource + testThousandeyesBadTestID,
+				ExpectError: regexp.MustCompile(`config: "test_id" must be an integer`),
+			},
+			{
+				Config:      testThousandeyesSource + testThousandeyesWrongBlock,
+				ExpectError: regexp.MustCompile(`webcheck block set for a feed of datasource .* which has source type thousandeyes`),
+			},
+		},
+	})
+}
+
 func TestAccDataFeed_updated(t *testing.T) {
 	var dataFeed data.Feed
 	resource.Test(t, resource.TestCase{
@@ -305,6 +351,49 @@ resource "ns1_datafeed" "uswest_feed" {
   }
 }`
 
+const testThousandeyesSource = `
+resource "ns1_datasource" "api" {
+  name = "terraform test"
+  sourcetype = "thousandeyes"
+}
+`
+
+const testThousandeyesMissingTestID = `
+resource "ns1_datafeed" "uswest_feed" {
+  name = "uswest_feed"
+  source_id = "${ns1_datasource.api.id}"
+  config = {
+    label = "uswest"
+  }
+}`
+
+const testThousandeyesBadTestID = `
+resource "ns1_datafeed" "uswest_feed" {
+  name = "uswest_feed"
+  source_id = "${ns1_datasource.api.id}"
+  config = {
+    test_id = "abc"
+  }
+}`
+
+const testThousandeyesTypedBlock = `
+resource "ns1_datafeed" "uswest_feed" {
+  name = "uswest_feed"
+  source_id = "${ns1_datasource.api.id}"
+  thousandeyes {
+    test_id = 123
+  }
+}`
+
+const testThousandeyesWrongBlock = `
+resource "ns1_datafeed" "uswest_feed" {
+  name = "uswest_feed"
+  source_id = "${ns1_datasource.api.id}"
+  webcheck {
+    check_id = 123
+  }
+}`
+
 const testDatadogBasic = `
 resource "ns1_datasource" "api" {
   name = "terraform test"
@@ -1,9 +1,12 @@
 package ns1
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
+	"strconv"
 	"time"
 
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
@@ -47,6 +50,21 @@ var ExportTypeStringEnum = NewStringEnum([]string{
 	"xlsx",
 })
 
+// datasetScopeDataKeys are the keys of datatype.data required by a scope.
+var datasetScopeDataKeys = map[string]string{
+	"network_single": "network_id",
+	"record_single":  "record_id",
+	"zone_single":    "zone_id",
+	"top_n_zones":    "n",
+	"top_n_records":  "n",
+}
+
+// datasetTypeInvalidScopes are the scopes a datatype can't be reported by.
+// Zones and records without queries can't be ranked by their queries.
+var datasetTypeInvalidScopes = map[string][]string{
+	"zero_queries": {"top_n_zones", "top_n_records"},
+}
+
 func datasetResource() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -54,27 +72,32 @@ func datasetResource() *schema.Resource {
 			"name": {
 				Type:             schema.TypeString,
 				Required:         true,
+				ForceNew:         true,
 				DiffSuppressFunc: caseSensitivityDiffSuppress,
 			},
 			"datatype": {
 				Type:     schema.TypeList,
 				Required: true,
+				ForceNew: true,
 				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"type": {
 							Type:         schema.TypeString,
 							Required:     true,
+							ForceNew:     true,
 							ValidateFunc: DatatypeTypeStringEnum.ValidateFunc,
 						},
 						"scope": {
 							Type:         schema.TypeString,
 							Required:     true,
+							ForceNew:     true,
 							ValidateFunc: DatatypeScopeStringEnum.ValidateFunc,
 						},
 						"data": {
 							Type:     schema.TypeMap,
 							Required: true,
+							ForceNew: true,
 							Elem:     schema.TypeString,
 						},
 					},
@@ -83,21 +106,25 @@ func datasetResource() *schema.Resource {
 			"repeat": {
 				Type:     schema.TypeList,
 				Optional: true,
+				ForceNew: true,
 				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"start": {
 							Type:     schema.TypeInt,
 							Required: true,
+							ForceNew: true,
 						},
 						"repeats_every": {
 							Type:         schema.TypeString,
 							Required:     true,
+							ForceNew:     true,
 							ValidateFunc: RepeatsEveryStringEnum.ValidateFunc,
 						},
 						"end_after_n": {
 							Type:     schema.TypeInt,
 							Required: true,
+							ForceNew: true,
 						},
 					},
 				},
@@ -105,25 +132,30 @@ func datasetResource() *schema.Resource {
 			"timeframe": {
 				Type:     schema.TypeList,
 				Required: true,
+				ForceNew: true,
 				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"aggregation": {
 							Type:         schema.TypeString,
 							Required:     true,
+							ForceNew:     true,
 							ValidateFunc: TimeframeAggregationStringEnum.ValidateFunc,
 						},
 						"cycles": {
 							Type:     schema.TypeInt,
 							Optional: true,
+							ForceNew: true,
 						},
 						"from": {
 							Type:     schema.TypeInt,
 							Optional: true,
+							ForceNew: true,
 						},
 						"to": {
 							Type:     schema.TypeInt,
 							Optional: true,
+							ForceNew: true,
 						},
 					},
 				},
@@ -131,11 +163,13 @@ func datasetResource() *schema.Resource {
 			"export_type": {
 				Type:         schema.TypeString,
 				Required:     true,
+				ForceNew:     true,
 				ValidateFunc: ExportTypeStringEnum.ValidateFunc,
 			},
 			"recipient_emails": {
 				Type:     schema.TypeSet,
 				Optional: true,
+				ForceNew: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 			// Read-only
@@ -172,11 +206,81 @@ func datasetResource() *schema.Resource {
 				},
 			},
 		},
-		Create: DatasetCreate,
-		Read:   DatasetRead,
-		Update: DatasetUpdate,
-		Delete: DatasetDelete,
+		Create:        DatasetCreate,
+		Read:          DatasetRead,
+		Delete:        DatasetDelete,
+		CustomizeDiff: datasetCustomizeDiff,
+	}
+}
+
+// datasetCustomizeDiff checks the combinations of fields the API rejects.
+func datasetCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.NewValueKnown("datatype.0.type") && d.NewValueKnown("datatype.0.scope") {
+		dtype := d.Get("datatype.0.type").(string)
+		scope := d.Get("datatype.0.scope").(string)
+		if containsString(datasetTypeInvalidScopes[dtype], scope) {
+			return fmt.Errorf("datatype %s can't have scope %s", dtype, scope)
+		}
+		if d.NewValueKnown("datatype.0.data") {
+			data := d.Get("datatype.0.data").(map[string]interface{})
+			if err := checkDatasetData(scope, data); err != nil {
+				return err
+			}
+		}
 	}
+
+	if err := checkDatasetTimeframe(d); err != nil {
+		return err
+	}
+
+	// A repeat starting in the past is only an error when it is sent, i.e.
+	// when the dataset is created. Every field forces a new dataset, so any
+	// change creates it again.
+	if (d.Id() == "" || d.HasChanges("name", "datatype", "repeat", "timeframe", "export_type", "recipient_emails")) && d.NewValueKnown("repeat.0.start") {
+		if start, ok := d.GetOk("repeat.0.start"); ok && int64(start.(int)) < time.Now().Unix() {
+			return fmt.Errorf("repeat.start (%d) is in the past", start.(int))
+		}
+	}
+	return nil
+}
+
+// checkDatasetData checks datatype.data has the key required by the scope.
+func checkDatasetData(scope string, data map[string]interface{}) error {
+	key, ok := datasetScopeDataKeys[scope]
+	if !ok {
+		return nil
+	}
+	v, _ := data[key].(string)
+	if v == "" {
+		return fmt.Errorf("datatype.data.%s is required for scope %s", key, scope)
+	}
+	if key == "n" {
+		if n, err := strconv.Atoi(v); err != nil || n < 1 {
+			return fmt.Errorf("datatype.data.n must be a positive integer, got %q", v)
+		}
+	}
+	return nil
+}
+
+func checkDatasetTimeframe(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("timeframe.0.from") || !d.NewValueKnown("timeframe.0.to") {
+		return nil
+	}
+	from := d.Get("timeframe.0.from").(int)
+	to := d.Get("timeframe.0.to").(int)
+	if from == 0 && to == 0 {
+		return nil
+	}
+	if d.Get("timeframe.0.aggregation").(string) == "billing_period" {
+		return errors.New("timeframe.from and timeframe.to can't be set with billing_period aggregation")
+	}
+	if from == 0 || to == 0 {
+		return errors.New("timeframe.from and timeframe.to must be set together")
+	}
+	if from >= to {
+		return fmt.Errorf("timeframe.from (%d) must be before timeframe.to (%d)", from, to)
+	}
+	return nil
 }
 
 // DatasetCreate creates a dataset
@@ -271,10 +375,6 @@ func DatasetDelete(d *schema.ResourceData, meta interface{}) error {
 }
 
 // DatasetUpdate updates the dataset from ns1
-func DatasetUpdate(d *schema.ResourceData, meta interface{}) error {
-	return errors.New("datasets cannot be updated")
-}
-
 func newUnixTimestamp(sec int64) *dataset.UnixTimestamp {
 	if sec == 0 {
 		return nil
@@ -1,10 +1,13 @@
 package ns1
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/dataset"
 	"testing"
@@ -32,6 +35,75 @@ func TestAccDataset_basic(t *testing.T) {
 		},
 	})
 }
+func TestDatasetValidation(t *testing.T) {
+	future := int(time.Now().Add(24 * time.Hour).Unix())
+	config := func(dtype, scope string, data map[string]interface{}, timeframe map[string]interface{}, start int) map[string]interface{} {
+		c := map[string]interface{}{
+			"name":        "dataset",
+			"datatype":    []interface{}{map[string]interface{}{"type": dtype, "scope": scope, "data": data}},
+			"timeframe":   []interface{}{timeframe},
+			"export_type": "csv",
+		}
+		if start != 0 {
+			c["repeat"] = []interface{}{map[string]interface{}{"start": start, "repeats_every": "month", "end_after_n": 1}}
+		}
+		return c
+	}
+	monthly := map[string]interface{}{"aggregation": "monthly", "cycles": 1}
+	none := map[string]interface{}{}
+
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+		errs   bool
+	}{
+		{"account", config("num_queries", "account", none, monthly, future), false},
+		{"zone", config("num_queries", "zone_single", map[string]interface{}{"zone_id": "abc"}, monthly, 0), false},
+		{"record", config("num_nxd_response", "record_single", map[string]interface{}{"record_id": "abc"}, monthly, 0), false},
+		{"network", config("num_ebot_response", "network_single", map[string]interface{}{"network_id": "0"}, monthly, 0), false},
+		{"top n", config("num_queries", "top_n_records", map[string]interface{}{"n": "10"}, monthly, 0), false},
+		{"zero queries", config("zero_queries", "zone_each", none, monthly, 0), false},
+		{"from to", config("num_queries", "account", none, map[string]interface{}{"aggregation": "daily", "from": 100, "to": 200}, 0), false},
+		{"zero queries top n", config("zero_queries", "top_n_records", map[string]interface{}{"n": "10"}, monthly, 0), true},
+		{"zone without id", config("num_queries", "zone_single", none, monthly, 0), true},
+		{"record without id", config("num_queries", "record_single", map[string]interface{}{"zone_id": "abc"}, monthly, 0), true},
+		{"network without id", config("num_queries", "network_single", none, monthly, 0), true},
+		{"top n without n", config("num_queries", "top_n_zones", none, monthly, 0), true},
+		{"top n not a number", config("num_queries", "top_n_zones", map[string]interface{}{"n": "ten"}, monthly, 0), true},
+		{"billing period from to", config("num_queries", "account", none, map[string]interface{}{"aggregation": "billing_period", "from": 100, "to": 200}, 0), true},
+		{"from without to", config("num_queries", "account", none, map[string]interface{}{"aggregation": "daily", "from": 100}, 0), true},
+		{"from after to", config("num_queries", "account", none, map[string]interface{}{"aggregation": "daily", "from": 200, "to": 100}, 0), true},
+		{"start in the past", config("num_queries", "account", none, monthly, 100), true},
+	}
+	r := datasetResource()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tt.config), nil)
+			assert.Equal(t, tt.errs, err != nil, "diff error: %v", err)
+		})
+	}
+
+	// The start of an existing dataset passes
+	existing := config("num_queries", "account", none, monthly, 100)
+	state := schema.TestResourceDataRaw(t, r.Schema, existing)
+	state.SetId("abc")
+	_, err := r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(existing), nil)
+	assert.NoError(t, err)
+
+	// but not when another change creates it again
+	renamed := config("num_queries", "account", none, monthly, 100)
+	renamed["name"] = "renamed"
+	_, err = r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(renamed), nil)
+	assert.ErrorContains(t, err, "repeat.start (100) is in the past")
+
+	// any change replaces the dataset, as it can't be updated
+	renamed = config("num_queries", "account", none, monthly, future)
+	renamed["name"] = "renamed"
+	diff, err := r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(renamed), nil)
+	require.NoError(t, err)
+	assert.True(t, diff.RequiresNew())
+}
+
 func testAccCheckDatasetExists(dt *dataset.Dataset, t *testing.T) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs := s.RootModule().Resources["ns1_dataset.my_dataset"]
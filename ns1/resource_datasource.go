@@ -1,6 +1,8 @@
 package ns1
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"strings"
 
@@ -18,39 +20,79 @@ func dataSourceResource() *schema.Resource {
 				Required: true,
 			},
 			"sourcetype": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSourceType,
 			},
 			"config": {
 				Type:     schema.TypeMap,
 				Optional: true,
 			},
+			"feed_url": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
 		},
-		Create:   DataSourceCreate,
-		Read:     DataSourceRead,
-		Update:   DataSourceUpdate,
-		Delete:   DataSourceDelete,
-		Importer: &schema.ResourceImporter{},
+		Create:        DataSourceCreate,
+		Read:          DataSourceRead,
+		Update:        DataSourceUpdate,
+		Delete:        DataSourceDelete,
+		Importer:      &schema.ResourceImporter{},
+		CustomizeDiff: dataSourceCustomizeDiff,
 	}
 }
 
-func dataSourceToResourceData(d *schema.ResourceData, s *data.Source) {
+func dataSourceToResourceData(d *schema.ResourceData, s *data.Source, client *ns1.Client) {
 	d.SetId(s.ID)
 	d.Set("name", s.Name)
 	d.Set("sourcetype", s.Type)
-	d.Set("config", s.Config)
+	d.Set("config", typedConfigOut(s.Config))
+	d.Set("feed_url", dataSourceFeedURL(client, s))
+}
+
+func resourceDataToDataSource(d *schema.ResourceData) (*data.Source, error) {
+	s := data.NewSource(d.Get("name").(string), d.Get("sourcetype").(string))
+	config, err := typedConfigIn(sourceConfigKinds(s.Type), d.Get("config").(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	s.Config = config
+	return s, nil
+}
+
+// dataSourceFeedURL returns the URL that feeds of push sources are published
+// to, or "" for sources NS1 polls itself.
+func dataSourceFeedURL(client *ns1.Client, s *data.Source) string {
+	if !sourceTypes[s.Type].Push || s.ID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%sfeed/%s", client.Endpoint.String(), s.ID)
+}
+
+func dataSourceCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("config") || !d.NewValueKnown("sourcetype") {
+		return nil
+	}
+	st, ok := sourceTypes[d.Get("sourcetype").(string)]
+	if !ok {
+		return nil
+	}
+	return checkTypedConfig(st.Config, d.Get("config").(map[string]interface{}))
 }
 
 // DataSourceCreate creates an ns1 datasource
 func DataSourceCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
-	s := data.NewSource(d.Get("name").(string), d.Get("sourcetype").(string))
-	s.Config = d.Get("config").(map[string]interface{})
+	s, err := resourceDataToDataSource(d)
+	if err != nil {
+		return err
+	}
 	if resp, err := client.DataSources.Create(s); err != nil {
 		return ConvertToNs1Error(resp, err)
 	}
-	dataSourceToResourceData(d, s)
+	dataSourceToResourceData(d, s, client)
 	return nil
 }
 
@@ -68,7 +110,7 @@ func DataSourceRead(d *schema.ResourceData, meta interface{}) error {
 
 		return ConvertToNs1Error(resp, err)
 	}
-	dataSourceToResourceData(d, s)
+	dataSourceToResourceData(d, s, client)
 	return nil
 }
 
@@ -83,11 +125,14 @@ func DataSourceDelete(d *schema.ResourceData, meta interface{}) error {
 // DataSourceUpdate updates the datasource with given parameters
 func DataSourceUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
-	s := data.NewSource(d.Get("name").(string), d.Get("sourcetype").(string))
+	s, err := resourceDataToDataSource(d)
+	if err != nil {
+		return err
+	}
 	s.ID = d.Id()
 	if resp, err := client.DataSources.Update(s); err != nil {
 		return ConvertToNs1Error(resp, err)
 	}
-	dataSourceToResourceData(d, s)
+	dataSourceToResourceData(d, s, client)
 	return nil
 }
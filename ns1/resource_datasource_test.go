@@ -25,6 +25,7 @@ func TestAccDataSource_basic(t *testing.T) {
 					testAccCheckDataSourceExists("ns1_datasource.foobar", &dataSource),
 					testAccCheckDataSourceName(&dataSource, "terraform test"),
 					testAccCheckDataSourceType(&dataSource, "nsone_v1"),
+					resource.TestCheckResourceAttrSet("ns1_datasource.foobar", "feed_url"),
 				),
 			},
 			{
@@ -57,6 +58,7 @@ func TestAccDataSource_updated(t *testing.T) {
 					testAccCheckDataSourceExists("ns1_datasource.foobar", &dataSource),
 					testAccCheckDataSourceName(&dataSource, "terraform test"),
 					testAccCheckDataSourceType(&dataSource, "nsone_monitoring"),
+					resource.TestCheckResourceAttr("ns1_datasource.foobar", "feed_url", ""),
 				),
 			},
 			{
@@ -3,14 +3,23 @@ package ns1
 import (
 	"fmt"
 	"log"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
 )
 
+// notifierTypes are the NS1 notifier types that have a typed block of the same
+// name; they are sent to the API in this order.
+var notifierTypes = []string{"email", "webhook", "slack", "pagerduty", "datafeed", "user"}
+
+var emailRegex = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
 func notifyListResource() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -19,8 +28,10 @@ func notifyListResource() *schema.Resource {
 				Required: true,
 			},
 			"notifications": {
-				Type:     schema.TypeSet,
-				Optional: true,
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Deprecated:    "use the typed notifier blocks (email, webhook, slack, pagerduty, datafeed, user) instead",
+				ConflictsWith: notifierTypes,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"type": {
@@ -34,6 +45,110 @@ func notifyListResource() *schema.Resource {
 					},
 				},
 			},
+			"email": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"notifications"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(emailRegex, "must be a valid email address"),
+						},
+					},
+				},
+			},
+			"webhook": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"notifications"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						},
+						// headers often carry authorization tokens
+						"headers": {
+							Type:      schema.TypeMap,
+							Optional:  true,
+							Sensitive: true,
+							Elem:      &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"slack": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"notifications"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// slack incoming webhook URLs embed their token
+						"url": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						},
+						"username": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"channel": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+			"pagerduty": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"notifications"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+			"datafeed": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"notifications"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+			"user": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"notifications"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateUsername,
+						},
+					},
+				},
+			},
 		},
 		Create:   NotifyListCreate,
 		Read:     NotifyListRead,
@@ -47,6 +162,17 @@ func notifyListToResourceData(d *schema.ResourceData, nl *monitor.NotifyList) er
 	d.SetId(nl.ID)
 	d.Set("name", nl.Name)
 
+	// keep the deprecated layout for lists that are still configured with it,
+	// and for lists with notifiers that have no typed block, so they are not lost
+	if _, ok := d.GetOk("notifications"); !ok && !hasUntypedNotifiers(nl.Notifications) {
+		return notifiersToResourceData(d, nl.Notifications)
+	}
+	for _, t := range notifierTypes {
+		if err := d.Set(t, nil); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting %s notifiers, error: %#v", t, err)
+		}
+	}
+
 	if len(nl.Notifications) > 0 {
 		notifications := make([]map[string]any, len(nl.Notifications))
 		for i, n := range nl.Notifications {
@@ -64,6 +190,7 @@ func notifyListToResourceData(d *schema.ResourceData, nl *monitor.NotifyList) er
 							for h, v := range headers {
 								headerLines = append(headerLines, fmt.Sprintf("%s: %s", h, v))
 							}
+							sort.Strings(headerLines)
 							cfg["headers"] = strings.Join(headerLines, "\n")
 						case map[string]string:
 							// this happens when not set, so ignore
@@ -79,7 +206,9 @@ func notifyListToResourceData(d *schema.ResourceData, nl *monitor.NotifyList) er
 			}
 			notifications[i] = ni
 		}
-		d.Set("notifications", notifications)
+		if err := d.Set("notifications", notifications); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting notifications, error: %#v", err)
+		}
 	}
 	return nil
 }
@@ -87,6 +216,19 @@ func notifyListToResourceData(d *schema.ResourceData, nl *monitor.NotifyList) er
 func resourceDataToNotifyList(nl *monitor.NotifyList, d *schema.ResourceData) error {
 	nl.ID = d.Id()
 
+	if ns := resourceDataToNotifiers(d); len(ns) > 0 {
+		// typed blocks replace the whole list, which would delete the
+		// notifiers that can only be kept in notifications
+		old, _ := d.GetChange("notifications")
+		for _, raw := range old.(*schema.Set).List() {
+			if t := raw.(map[string]interface{})["type"].(string); !containsString(notifierTypes, t) {
+				return fmt.Errorf("notify list has %s notifiers, which have no typed block and would be deleted; keep configuring it with notifications", t)
+			}
+		}
+		nl.Notifications = ns
+		return nil
+	}
+
 	if rawNotifications := d.Get("notifications").(*schema.Set); rawNotifications.Len() > 0 {
 		ns := make([]*monitor.Notification, 0, rawNotifications.Len())
 		for _, notificationRaw := range rawNotifications.List() {
@@ -148,7 +290,12 @@ func resourceDataToNotifyList(nl *monitor.NotifyList, d *schema.ResourceData) er
 						return fmt.Errorf("wrong config for slack expected url, username and channel fields into config")
 					}
 				default:
-					return fmt.Errorf("%s is not a valid notifier type", ni["type"])
+					// notifier types without a typed block are sent as configured
+					cfg := make(monitor.Config, len(config))
+					for k, v := range config {
+						cfg[k] = v
+					}
+					ns = append(ns, &monitor.Notification{Type: ni["type"].(string), Config: cfg})
 				}
 			}
 		}
@@ -157,6 +304,104 @@ func resourceDataToNotifyList(nl *monitor.NotifyList, d *schema.ResourceData) er
 	return nil
 }
 
+// hasUntypedNotifiers reports whether some notifiers have no typed block.
+func hasUntypedNotifiers(notifications []*monitor.Notification) bool {
+	for _, n := range notifications {
+		if !containsString(notifierTypes, n.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// notifiersToResourceData sets the typed notifier blocks. Notifier types without
+// a typed block are skipped, notifyListToResourceData keeps lists that have
+// them in notifications.
+func notifiersToResourceData(d *schema.ResourceData, notifications []*monitor.Notification) error {
+	blocks := make(map[string][]map[string]interface{})
+	for _, n := range notifications {
+		var m map[string]interface{}
+		switch n.Type {
+		case "email":
+			m = map[string]interface{}{"address": configString(n.Config, "email")}
+		case "webhook":
+			headers := make(map[string]string)
+			if h, ok := n.Config["headers"].(map[string]interface{}); ok {
+				for k, v := range h {
+					headers[k] = fmt.Sprintf("%v", v)
+				}
+			}
+			m = map[string]interface{}{
+				"url":     configString(n.Config, "url"),
+				"headers": headers,
+			}
+		case "slack":
+			m = map[string]interface{}{
+				"url":      configString(n.Config, "url"),
+				"username": configString(n.Config, "username"),
+				"channel":  configString(n.Config, "channel"),
+			}
+		case "pagerduty":
+			m = map[string]interface{}{"service_key": configString(n.Config, "service_key")}
+		case "datafeed":
+			m = map[string]interface{}{"source_id": configString(n.Config, "sourceid")}
+		case "user":
+			m = map[string]interface{}{"username": configString(n.Config, "user")}
+		default:
+			log.Printf("[WARN] NS1 notifier type %q has no typed block, ignoring it", n.Type)
+			continue
+		}
+		blocks[n.Type] = append(blocks[n.Type], m)
+	}
+
+	for _, t := range notifierTypes {
+		if err := d.Set(t, blocks[t]); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting %s notifiers, error: %#v", t, err)
+		}
+	}
+	return nil
+}
+
+// resourceDataToNotifiers builds the notifications from the typed notifier
+// blocks, grouped by type so the list sent to the API is stable.
+func resourceDataToNotifiers(d *schema.ResourceData) []*monitor.Notification {
+	ns := make([]*monitor.Notification, 0)
+	for _, t := range notifierTypes {
+		for _, raw := range d.Get(t).(*schema.Set).List() {
+			m := raw.(map[string]interface{})
+			switch t {
+			case "email":
+				ns = append(ns, monitor.NewEmailNotification(m["address"].(string)))
+			case "webhook":
+				var headers map[string]string
+				if h := m["headers"].(map[string]interface{}); len(h) > 0 {
+					headers = make(map[string]string, len(h))
+					for k, v := range h {
+						headers[k] = v.(string)
+					}
+				}
+				ns = append(ns, monitor.NewWebNotification(m["url"].(string), headers))
+			case "slack":
+				ns = append(ns, monitor.NewSlackNotification(m["url"].(string), m["username"].(string), m["channel"].(string)))
+			case "pagerduty":
+				ns = append(ns, monitor.NewPagerDutyNotification(m["service_key"].(string)))
+			case "datafeed":
+				ns = append(ns, monitor.NewFeedNotification(m["source_id"].(string)))
+			case "user":
+				ns = append(ns, monitor.NewUserNotification(m["username"].(string)))
+			}
+		}
+	}
+	return ns
+}
+
+func configString(cfg monitor.Config, key string) string {
+	if v, ok := cfg[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
 // NotifyListCreate creates an ns1 notifylist
 func NotifyListCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
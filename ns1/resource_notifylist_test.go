@@ -1,11 +1,14 @@
 package ns1
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
@@ -136,6 +139,200 @@ func TestAccNotifyList_types(t *testing.T) {
 	})
 }
 
+func TestAccNotifyList_typed(t *testing.T) {
+	var nl monitor.NotifyList
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNotifyListDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotifyListTyped,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotifyListExists("ns1_notifylist.test_typed", &nl),
+					testAccCheckNotifyListName(&nl, "terraform test typed"),
+					resource.TestCheckResourceAttr("ns1_notifylist.test_typed", "webhook.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "ns1_notifylist.test_typed",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccNotifyListTypedUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotifyListExists("ns1_notifylist.test_typed", &nl),
+					testAccCheckNotifyListName(&nl, "terraform test typed"),
+				),
+			},
+			{
+				Config: testAccNotifyListTypedMultipleHeaders,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotifyListExists("ns1_notifylist.test_typed", &nl),
+					testAccCheckNotifyListName(&nl, "terraform test typed"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNotifyList_typedMultiple(t *testing.T) {
+	var nl monitor.NotifyList
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNotifyListDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotifyListTypedMultiple,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotifyListExists("ns1_notifylist.test_typed_multiple", &nl),
+					testAccCheckNotifyListName(&nl, "terraform test typed multiple"),
+					testAccCheckNotifyTypeOrder(&nl, "email", "webhook"),
+				),
+			},
+			// typed blocks are sent grouped by type, whatever their order in the config
+			{
+				Config: testAccNotifyListTypedMultipleDifferentOrder,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotifyListExists("ns1_notifylist.test_typed_multiple", &nl),
+					testAccCheckNotifyListName(&nl, "terraform test typed multiple different order"),
+					testAccCheckNotifyTypeOrder(&nl, "email", "webhook"),
+				),
+			},
+			{
+				ResourceName:      "ns1_notifylist.test_typed_multiple",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccNotifyListTypedSlack,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotifyListExists("ns1_notifylist.test_typed_slack", &nl),
+					testAccCheckNotifyListName(&nl, "terraform test typed slack"),
+				),
+			},
+			{
+				ResourceName:      "ns1_notifylist.test_typed_slack",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNotifyList_legacy(t *testing.T) {
+	var nl monitor.NotifyList
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNotifyListDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotifyListLegacy,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotifyListExists("ns1_notifylist.test_legacy", &nl),
+					testAccCheckNotifyListName(&nl, "terraform test legacy"),
+				),
+			},
+			// the headers must come back in the same order, otherwise the set churns
+			{
+				Config:   testAccNotifyListLegacy,
+				PlanOnly: true,
+			},
+			{
+				Config: testAccNotifyListLegacyToTyped,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotifyListExists("ns1_notifylist.test_legacy", &nl),
+					resource.TestCheckResourceAttr("ns1_notifylist.test_legacy", "notifications.#", "0"),
+					resource.TestCheckResourceAttr("ns1_notifylist.test_legacy", "webhook.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestNotifiersRoundTrip(t *testing.T) {
+	notifications := []*monitor.Notification{
+		monitor.NewPagerDutyNotification("key"),
+		monitor.NewWebNotification("https://example.com/hook", map[string]string{"Accept": "application/json"}),
+		monitor.NewEmailNotification("tf-test@example.com"),
+		{Type: "hipchat", Config: monitor.Config{"token": "t", "room": "r"}},
+	}
+	// headers come back from the API as a JSON object
+	notifications[1].Config["headers"] = map[string]interface{}{"Accept": "application/json"}
+
+	d := notifyListResource().TestResourceData()
+	if err := notifiersToResourceData(d, notifications); err != nil {
+		t.Fatal(err)
+	}
+
+	ns := resourceDataToNotifiers(d)
+	if len(ns) != 3 {
+		t.Fatalf("got %d notifiers, want 3", len(ns))
+	}
+	for i, expected := range []string{"email", "webhook", "pagerduty"} {
+		if ns[i].Type != expected {
+			t.Errorf("notifier %d: got type %q, want %q", i, ns[i].Type, expected)
+		}
+	}
+	if headers := ns[1].Config["headers"].(map[string]string); headers["Accept"] != "application/json" {
+		t.Errorf("webhook headers: got %#v", headers)
+	}
+}
+
+func TestNotifyListUntypedNotifiers(t *testing.T) {
+	r := notifyListResource()
+	nl := &monitor.NotifyList{ID: "abc", Name: "list", Notifications: []*monitor.Notification{
+		monitor.NewEmailNotification("tf-test@example.com"),
+		{Type: "hipchat", Config: monitor.Config{"token": "t", "room": "r"}},
+	}}
+
+	// lists with notifiers that have no typed block are kept in notifications
+	d := r.TestResourceData()
+	if err := notifyListToResourceData(d, nl); err != nil {
+		t.Fatal(err)
+	}
+	if n := d.Get("notifications").(*schema.Set).Len(); n != 2 {
+		t.Fatalf("got %d notifications, want 2", n)
+	}
+	if n := d.Get("email").(*schema.Set).Len(); n != 0 {
+		t.Fatalf("got %d email blocks, want 0", n)
+	}
+
+	// and are sent back as they are
+	out := monitor.NewNotifyList("list")
+	if err := resourceDataToNotifyList(out, d); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Notifications) != 2 {
+		t.Fatalf("got %d notifications, want 2", len(out.Notifications))
+	}
+	for _, n := range out.Notifications {
+		if n.Type == "hipchat" && n.Config["room"] != "r" {
+			t.Errorf("hipchat config: got %#v", n.Config)
+		}
+	}
+
+	// switching such a list to typed blocks would delete them
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":  "list",
+		"email": []interface{}{map[string]interface{}{"address": "tf-test@example.com"}},
+	}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated, err := schema.InternalMap(r.Schema).Data(d.State(), diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = resourceDataToNotifyList(monitor.NewNotifyList("list"), updated)
+	if err == nil || !strings.Contains(err.Error(), "hipchat notifiers") {
+		t.Fatalf("got error %v, want hipchat notifiers error", err)
+	}
+}
+
 func TestAccNotifyList_ManualDelete(t *testing.T) {
 	var nl monitor.NotifyList
 
@@ -348,3 +545,104 @@ resource "ns1_notifylist" "test_multiple" {
   }
 }
 `
+
+const testAccNotifyListTyped = `
+resource "ns1_notifylist" "test_typed" {
+  name = "terraform test typed"
+  webhook {
+    url = "http://localhost:9090"
+  }
+}
+`
+
+const testAccNotifyListTypedUpdated = `
+resource "ns1_notifylist" "test_typed" {
+  name = "terraform test typed"
+  webhook {
+    url = "http://localhost:9091"
+    headers = {
+      "Content-Type" = "application/json"
+    }
+  }
+}
+`
+
+const testAccNotifyListTypedMultipleHeaders = `
+resource "ns1_notifylist" "test_typed" {
+  name = "terraform test typed"
+  webhook {
+    url = "http://localhost:9091"
+    headers = {
+      "Content-Type" = "application/json"
+      "Accept"       = "application/json"
+    }
+  }
+}
+`
+
+const testAccNotifyListLegacy = `
+resource "ns1_notifylist" "test_legacy" {
+  name = "terraform test legacy"
+  notifications {
+    type = "webhook"
+    config = {
+      url = "http://localhost:9091"
+			headers = "Accept: application/json\nContent-Type: application/json"
+    }
+  }
+}
+`
+
+const testAccNotifyListLegacyToTyped = `
+resource "ns1_notifylist" "test_legacy" {
+  name = "terraform test legacy"
+  webhook {
+    url = "http://localhost:9091"
+    headers = {
+      "Accept"       = "application/json"
+      "Content-Type" = "application/json"
+    }
+  }
+}
+`
+
+const testAccNotifyListTypedSlack = `
+resource "ns1_notifylist" "test_typed_slack" {
+  name = "terraform test typed slack"
+  slack {
+    username = "tf-test"
+    url = "http://localhost:9091"
+    channel = "TF Test Channel"
+  }
+}
+`
+
+const testAccNotifyListTypedMultiple = `
+resource "ns1_notifylist" "test_typed_multiple" {
+  name = "terraform test typed multiple"
+  pagerduty {
+    service_key = "tftestkey"
+  }
+  webhook {
+    url = "http://localhost:9090"
+  }
+  email {
+    address = "tf-test@example.com"
+  }
+}
+`
+
+const testAccNotifyListTypedMultipleDifferentOrder = `
+resource "ns1_notifylist" "test_typed_multiple" {
+  name = "terraform test typed multiple different order"
+  email {
+    address = "tf-test@example.com"
+  }
+  webhook {
+    url = "http://localhost:9090"
+  }
+  pagerduty {
+    service_key = "tftestkey"
+  }
+}
+`
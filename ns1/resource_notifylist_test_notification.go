@@ -0,0 +1,185 @@
+package ns1
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/alerting"
+)
+
+// notifyListTestResource tests a notification list by firing a test of an
+// alert that uses it. NS1 only tests alerts, so every list of the alert is
+// notified.
+func notifyListTestResource() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"notify_list_id": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"alert_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			ForceNew: true,
+		},
+		"trigger": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			ForceNew: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		// Read-only
+		"sent_at": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"notify_list_ids": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+	// The notifiers of the list, as in ns1_notifylist.
+	notifyList := notifyListResource()
+	for _, t := range notifierTypes {
+		s[t] = computedSchema(notifyList.Schema[t])
+	}
+
+	return &schema.Resource{
+		Schema: s,
+		Create: NotifyListTestCreate,
+		Read:   NotifyListTestRead,
+		Delete: NotifyListTestDelete,
+	}
+}
+
+// notifyListTestAlert returns the alert to fire to test a list: the first
+// alert, by ID, that only notifies the list.
+func notifyListTestAlert(alerts []*alerting.Alert, listID string) (string, error) {
+	var ids, others []string
+	for _, a := range alerts {
+		if a.ID == nil || !containsString(a.NotifierListIds, listID) {
+			continue
+		}
+		if len(a.NotifierListIds) == 1 {
+			ids = append(ids, *a.ID)
+		} else {
+			others = append(others, *a.ID)
+		}
+	}
+	if len(ids) > 0 {
+		sort.Strings(ids)
+		return ids[0], nil
+	}
+	if len(others) > 0 {
+		sort.Strings(others)
+		return "", fmt.Errorf("the alerts that notify list %s also notify other lists, set alert_id to one of %v to notify them too", listID, others)
+	}
+	return "", fmt.Errorf("no alert notifies list %s, it can't be tested", listID)
+}
+
+// NotifyListTestCreate fires a test of the alert. NS1 accepts or rejects
+// the test of the alert as a whole, and does not report its delivery, so a
+// rejected test fails the apply.
+func NotifyListTestCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	listID := d.Get("notify_list_id").(string)
+
+	nl, resp, err := client.Notifications.Get(listID)
+	if err != nil {
+		if err == ns1.ErrListMissing {
+			return fmt.Errorf("notify list %s not found, it can't be tested", listID)
+		}
+		return ConvertToNs1Error(resp, err)
+	}
+
+	var alert *alerting.Alert
+	if alertID, ok := d.GetOk("alert_id"); ok {
+		alert, resp, err = client.Alerts.Get(alertID.(string))
+		if err != nil {
+			if err == ns1.ErrAlertMissing {
+				return fmt.Errorf("alert %s not found, notify list %s can't be tested with it", alertID, listID)
+			}
+			return ConvertToNs1Error(resp, err)
+		}
+		if !containsString(alert.NotifierListIds, listID) {
+			return fmt.Errorf("alert %s does not notify list %s", alertID, listID)
+		}
+	} else {
+		alerts, resp, err := client.Alerts.List()
+		if err != nil {
+			return ConvertToNs1Error(resp, err)
+		}
+		alertID, err := notifyListTestAlert(alerts, listID)
+		if err != nil {
+			return err
+		}
+		for _, a := range alerts {
+			if a.ID != nil && *a.ID == alertID {
+				alert = a
+			}
+		}
+	}
+
+	if resp, err := client.Alerts.Test(*alert.ID); err != nil {
+		return fmt.Errorf("testing notify list %s with alert %s: %s", listID, *alert.ID, ConvertToNs1Error(resp, err))
+	}
+
+	sentAt := time.Now().Unix()
+	d.SetId(fmt.Sprintf("%s/%s/%d", listID, *alert.ID, sentAt))
+	d.Set("alert_id", *alert.ID)
+	d.Set("sent_at", sentAt)
+	d.Set("notify_list_ids", alert.NotifierListIds)
+	return notifiersToResourceData(d, nl.Notifications)
+}
+
+// NotifyListTestRead refreshes the notifiers of the list. The test is
+// removed from the state when the list or the alert is deleted, and is
+// only sent again when notify_list_id, alert_id or trigger change.
+func NotifyListTestRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	listID := d.Get("notify_list_id").(string)
+
+	nl, resp, err := client.Notifications.Get(listID)
+	if err != nil {
+		if err == ns1.ErrListMissing {
+			log.Printf("[DEBUG] NS1 notify list (%s) not found", listID)
+			d.SetId("")
+			return nil
+		}
+		return ConvertToNs1Error(resp, err)
+	}
+
+	alertID := d.Get("alert_id").(string)
+	alert, resp, err := client.Alerts.Get(alertID)
+	if err != nil {
+		if err == ns1.ErrAlertMissing {
+			log.Printf("[DEBUG] NS1 alert (%s) not found", alertID)
+			d.SetId("")
+			return nil
+		}
+		return ConvertToNs1Error(resp, err)
+	}
+	if !containsString(alert.NotifierListIds, listID) {
+		log.Printf("[DEBUG] NS1 alert (%s) no longer notifies list (%s)", alertID, listID)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("notify_list_ids", alert.NotifierListIds); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting notify_list_ids, error: %#v", err)
+	}
+	return notifiersToResourceData(d, nl.Notifications)
+}
+
+// NotifyListTestDelete removes the test from the state
+func NotifyListTestDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,139 @@
+package ns1
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/alerting"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+func TestAccNotifyListTest_basic(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAlertDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotifyListTest(rString, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ns1_notifylist_test.it", "sent_at"),
+					resource.TestCheckResourceAttrPair("ns1_notifylist_test.it", "alert_id", "ns1_alert.it", "id"),
+					resource.TestCheckResourceAttrPair("ns1_notifylist_test.it", "notify_list_ids.0", "ns1_notifylist.it", "id"),
+					resource.TestCheckResourceAttr("ns1_notifylist_test.it", "email.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("ns1_notifylist_test.it", "email.*", map[string]string{
+						"address": "jdoe@example.com",
+					}),
+				),
+			},
+			{
+				Config:             testAccNotifyListTest(rString, "2"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestNotifyListTestAlert(t *testing.T) {
+	id := func(s string) *string { return &s }
+	alerts := []*alerting.Alert{
+		{ID: id("c"), NotifierListIds: []string{"list"}},
+		{ID: id("a"), NotifierListIds: []string{"list", "other"}},
+		{ID: id("b"), NotifierListIds: []string{"list"}},
+		{ID: id("d"), NotifierListIds: []string{"other"}},
+	}
+
+	alertID, err := notifyListTestAlert(alerts, "list")
+	require.NoError(t, err)
+	assert.Equal(t, "b", alertID)
+
+	_, err = notifyListTestAlert(alerts[1:2], "list")
+	assert.EqualError(t, err, "the alerts that notify list list also notify other lists, set alert_id to one of [a] to notify them too")
+	_, err = notifyListTestAlert(alerts, "missing")
+	assert.EqualError(t, err, "no alert notifies list missing, it can't be tested")
+}
+
+func TestNotifyListTestRead_deleted(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.NoError(t, err)
+	defer mock.Shutdown()
+	client := ns1.NewClient(doer, ns1.SetAPIKey("apikey"))
+	client.Endpoint, _ = url.Parse("https://" + mock.Address + "/v1/")
+
+	id := func(s string) *string { return &s }
+	missing := map[string]string{"message": "resource not found"}
+	require.NoError(t, mock.AddTestCase(http.MethodGet, "lists/gone", http.StatusNotFound, nil, nil, "", missing))
+	for _, l := range []string{"list", "other"} {
+		require.NoError(t, mock.AddTestCase(http.MethodGet, "lists/"+l, http.StatusOK, nil, nil, "", &monitor.NotifyList{ID: l}))
+	}
+	require.NoError(t, mock.AddTestCase(http.MethodGet, "../alerting/v1/alerts/gone", http.StatusNotFound, nil, nil, "", missing))
+	require.NoError(t, mock.AddAlertGetTestCase("alert", nil, nil, &alerting.Alert{
+		ID: id("alert"), NotifierListIds: []string{"list"},
+	}))
+
+	cases := map[string]struct {
+		list, alert string
+		kept        bool
+	}{
+		"kept":          {"list", "alert", true},
+		"list deleted":  {"gone", "alert", false},
+		"alert deleted": {"list", "gone", false},
+		"list removed":  {"other", "alert", false},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, notifyListTestResource().Schema, map[string]interface{}{
+				"notify_list_id": c.list,
+				"alert_id":       c.alert,
+			})
+			d.SetId("test")
+
+			require.NoError(t, NotifyListTestRead(d, client))
+			if c.kept {
+				assert.Equal(t, "test", d.Id())
+				assert.Equal(t, []interface{}{"list"}, d.Get("notify_list_ids"))
+			} else {
+				assert.Empty(t, d.Id())
+			}
+		})
+	}
+}
+
+func testAccNotifyListTest(rString, trigger string) string {
+	return fmt.Sprintf(`resource "ns1_notifylist" "it" {
+  name = "terraform test list %[1]s"
+
+  email {
+    address = "jdoe@example.com"
+  }
+}
+
+resource "ns1_alert" "it" {
+  name               = "terraform-test-alert-%[1]s"
+  type               = "zone"
+  subtype            = "transfer_failed"
+  notification_lists = [ns1_notifylist.it.id]
+}
+
+resource "ns1_notifylist_test" "it" {
+  notify_list_id = ns1_notifylist.it.id
+  alert_id       = ns1_alert.it.id
+
+  trigger = {
+    run = "%[2]s"
+  }
+}
+`, rString, trigger)
+}
@@ -1,12 +1,14 @@
 package ns1
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"regexp"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/redirect"
@@ -93,12 +95,37 @@ func redirectConfigResource() *schema.Resource {
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			"manage_dns_record": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"dns_record_target": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateDomain,
+				DiffSuppressFunc: redirectDNSRecordTargetDiffSuppress,
+			},
+			"adopt_dns_record": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"dns_record_zone": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		Create:        RedirectConfigCreate,
+		Read:          RedirectConfigRead,
+		Update:        RedirectConfigUpdate,
+		Delete:        RedirectConfigDelete,
+		Importer:      &schema.ResourceImporter{State: redirectConfigImportStateFunc},
+		CustomizeDiff: redirectConfigCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 		},
-		Create:   RedirectConfigCreate,
-		Read:     RedirectConfigRead,
-		Update:   RedirectConfigUpdate,
-		Delete:   RedirectConfigDelete,
-		Importer: &schema.ResourceImporter{},
 	}
 }
 
@@ -142,13 +169,128 @@ func redirectCertificateResource() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			// Optional
+			"wait_for_issuance": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"renew_before_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 		},
-		Create: RedirectCertCreate,
-		Read:   RedirectCertRead,
-		// Update:   RedirectCertUpdate,
-		Delete:   RedirectCertDelete,
-		Importer: &schema.ResourceImporter{},
+		Create:        RedirectCertCreate,
+		Read:          RedirectCertRead,
+		Update:        RedirectCertUpdate,
+		Delete:        RedirectCertDelete,
+		Importer:      &schema.ResourceImporter{State: redirectCertImportStateFunc},
+		CustomizeDiff: redirectCertCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+// redirectCertPollInterval is how often a certificate is read while waiting
+// for it to be issued.
+var redirectCertPollInterval = 5 * time.Second
+
+// redirectCertIssued reports whether a certificate has been issued, and
+// returns the errors of the certificate as an error.
+func redirectCertIssued(c *redirect.Certificate) (bool, error) {
+	if c.Errors != nil && *c.Errors != "" {
+		return false, fmt.Errorf("redirect certificate for %s failed: %s", c.Domain, *c.Errors)
+	}
+	if c.Processing != nil && *c.Processing {
+		return false, nil
+	}
+	return c.Certificate != nil && *c.Certificate != "", nil
+}
+
+// waitForRedirectCert reads a certificate until it is issued with a
+// valid_until after validAfter, it fails, or the timeout passes.
+func waitForRedirectCert(client *ns1.Client, id string, validAfter int64, timeout time.Duration) (*redirect.Certificate, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		cert, resp, err := client.RedirectCertificates.Get(id)
+		if err != nil {
+			return nil, ConvertToNs1Error(resp, err)
+		}
+		issued, err := redirectCertIssued(cert)
+		if err != nil {
+			return cert, err
+		}
+		if issued && cert.ValidUntil != nil && *cert.ValidUntil > validAfter {
+			return cert, nil
+		}
+		if time.Now().After(deadline) {
+			return cert, fmt.Errorf("timed out after %s waiting for redirect certificate %s to be issued", timeout, id)
+		}
+		log.Printf("[DEBUG] waiting for NS1 redirect certificate (%s) to be issued", id)
+		time.Sleep(redirectCertPollInterval)
+	}
+}
+
+// redirectCertRenewalDue reports whether a certificate valid until
+// validUntil is within days of expiring.
+func redirectCertRenewalDue(validUntil int64, days int, now time.Time) bool {
+	if days == 0 || validUntil == 0 {
+		return false
+	}
+	return now.Add(time.Duration(days) * 24 * time.Hour).After(time.Unix(validUntil, 0))
+}
+
+func redirectCertCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if redirectCertRenewalDue(int64(d.Get("valid_until").(int)), d.Get("renew_before_days").(int), time.Now()) {
+		for _, k := range []string{"certificate", "valid_from", "valid_until", "last_updated"} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setRedirectCertificate enables HTTPS on a redirect when it has a
+// certificate. A certificate that is being issued is waited for, and one
+// that does not exist or is not issued before the timeout is an error rather
+// than a redirect without HTTPS.
+func setRedirectCertificate(client *ns1.Client, d *schema.ResourceData, r *redirect.Configuration, timeout time.Duration) error {
+	id := getStringp(d, "certificate_id")
+	if id == nil {
+		f := false
+		r.HttpsEnabled = &f
+		return nil
+	}
+
+	cert, resp, err := client.RedirectCertificates.Get(*id)
+	if err != nil {
+		if err == ns1.ErrRedirectCertificateNotFound {
+			return fmt.Errorf("redirect certificate %s not found, HTTPS can't be enabled for %s", *id, r.Domain)
+		}
+		return ConvertToNs1Error(resp, err)
+	}
+	issued, err := redirectCertIssued(cert)
+	if err != nil {
+		return err
 	}
+	if !issued {
+		if _, err := waitForRedirectCert(client, *id, 0, timeout); err != nil {
+			return fmt.Errorf("HTTPS can't be enabled for %s: %s", r.Domain, err)
+		}
+	}
+
+	r.CertificateID = id
+	t := true
+	r.HttpsEnabled = &t
+	return nil
 }
 
 // RedirectConfigCreate creates a redirect configuration
@@ -171,28 +313,19 @@ func RedirectConfigCreate(d *schema.ResourceData, meta interface{}) error {
 		r.Tags = append(r.Tags, t.(string))
 	}
 
-	cert := getStringp(d, "certificate_id")
-	if cert != nil {
-		_, _, err := client.RedirectCertificates.Get(*cert)
-		if err == ns1.ErrRedirectCertificateNotFound {
-			cert = nil
-		}
-	}
-	if cert != nil {
-		r.CertificateID = cert
-		t := true
-		r.HttpsEnabled = &t
-	} else {
-		f := false
-		r.HttpsEnabled = &f
+	if err := setRedirectCertificate(client, d, r, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
 	}
 
 	cfg, resp, err := client.Redirects.Create(r)
 	if err != nil {
 		return ConvertToNs1Error(resp, err)
 	}
+	if err := redirectConfigToResourceData(d, cfg); err != nil {
+		return err
+	}
 
-	return redirectConfigToResourceData(d, cfg)
+	return applyRedirectDNSRecord(client, d)
 }
 
 // RedirectConfigRead reads the redirect config from ns1
@@ -210,15 +343,27 @@ func RedirectConfigRead(d *schema.ResourceData, meta interface{}) error {
 		return ConvertToNs1Error(resp, err)
 	}
 
-	return redirectConfigToResourceData(d, cfg)
+	if err := redirectConfigToResourceData(d, cfg); err != nil {
+		return err
+	}
+	return readRedirectDNSRecord(client, d)
 }
 
 // RedirectConfigDelete deletes the redirect config from ns1
 func RedirectConfigDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
-	resp, err := client.Redirects.Delete(d.Get("id").(string))
+	id := d.Get("id").(string)
+	resp, err := client.Redirects.Delete(id)
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	if zone := d.Get("dns_record_zone").(string); zone != "" {
+		if err := releaseRedirectDNSRecord(client, id, d.Get("domain").(string), zone); err != nil {
+			return err
+		}
+	}
 	d.SetId("")
-	return ConvertToNs1Error(resp, err)
+	return nil
 }
 
 // RedirectConfigUpdate updates the given redirect config in ns1
@@ -243,27 +388,21 @@ func RedirectConfigUpdate(d *schema.ResourceData, meta interface{}) error {
 		r.Tags = append(r.Tags, t.(string))
 	}
 
-	cert := getStringp(d, "certificate_id")
-	if cert != nil {
-		_, _, err := client.RedirectCertificates.Get(*cert)
-		if err == ns1.ErrRedirectCertificateNotFound {
-			cert = nil
-		}
-	}
-	if cert != nil {
-		r.CertificateID = cert
-		t := true
-		r.HttpsEnabled = &t
-	} else {
-		f := false
-		r.HttpsEnabled = &f
+	if err := setRedirectCertificate(client, d, r, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return err
 	}
 
 	cfg, resp, err := client.Redirects.Update(r)
 	if err != nil {
 		return ConvertToNs1Error(resp, err)
 	}
-	return redirectConfigToResourceData(d, cfg)
+	if err := redirectConfigToResourceData(d, cfg); err != nil {
+		return err
+	}
+	if d.HasChanges("domain", "manage_dns_record", "dns_record_target", "adopt_dns_record") {
+		return applyRedirectDNSRecord(client, d)
+	}
+	return nil
 }
 
 // RedirectCertCreate creates a redirect certificate
@@ -274,8 +413,20 @@ func RedirectCertCreate(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return ConvertToNs1Error(resp, err)
 	}
+	if err := redirectCertToResourceData(d, cert); err != nil {
+		return err
+	}
 
-	return redirectCertToResourceData(d, cert)
+	if !d.Get("wait_for_issuance").(bool) {
+		return nil
+	}
+	// The certificate is kept in the state when issuing fails, so that it is
+	// replaced on the next apply.
+	cert, err = waitForRedirectCert(client, d.Id(), 0, d.Timeout(schema.TimeoutCreate))
+	if cert != nil {
+		redirectCertToResourceData(d, cert)
+	}
+	return err
 }
 
 // RedirectCertRead reads the redirect certificate from ns1
@@ -327,11 +478,40 @@ func RedirectCertDelete(d *schema.ResourceData, meta interface{}) error {
 	return ConvertToNs1Error(resp, err)
 }
 
-// RedirectCertUpdate updates the given redirect certificate in ns1
+// RedirectCertUpdate renews the given redirect certificate in ns1 when it is
+// within renew_before_days of expiring
 func RedirectCertUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
-	resp, err := client.RedirectCertificates.Update(d.Id())
-	return ConvertToNs1Error(resp, err)
+	// The plan leaves valid_until unknown when the certificate is renewed, so
+	// the state value is used.
+	validUntil, _ := d.GetChange("valid_until")
+	if !redirectCertRenewalDue(int64(validUntil.(int)), d.Get("renew_before_days").(int), time.Now()) {
+		return RedirectCertRead(d, meta)
+	}
+
+	if resp, err := client.RedirectCertificates.Update(d.Id()); err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	if !d.Get("wait_for_issuance").(bool) {
+		return RedirectCertRead(d, meta)
+	}
+	cert, err := waitForRedirectCert(client, d.Id(), int64(validUntil.(int)), d.Timeout(schema.TimeoutUpdate))
+	if cert != nil {
+		redirectCertToResourceData(d, cert)
+	}
+	return err
+}
+
+func redirectConfigImportStateFunc(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("manage_dns_record", false)
+	d.Set("adopt_dns_record", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+func redirectCertImportStateFunc(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("wait_for_issuance", true)
+	d.Set("renew_before_days", 0)
+	return []*schema.ResourceData{d}, nil
 }
 
 // validateDomain verifies that the string matches a valid FQDN.
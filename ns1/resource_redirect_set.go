@@ -0,0 +1,713 @@
+package ns1
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/redirect"
+)
+
+// redirectSetCSVColumns are the columns of the csv argument, in the order
+// they are written back to the state. Only the first three are required.
+var redirectSetCSVColumns = []string{"domain", "path", "target", "forwarding_type", "forwarding_mode", "https_enabled", "https_forced"}
+
+func redirectSetResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			// Required
+			"domains": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateDomain,
+				},
+			},
+			// Optional
+			"redirect": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"csv"},
+				Set:           hashRedirectSetEntry,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateDomain,
+						},
+						"path": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validatePath,
+						},
+						"target": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateURL,
+						},
+						"forwarding_mode": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "all",
+							ValidateFunc: forwardingModeStringEnum.ValidateFunc,
+						},
+						"forwarding_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "permanent",
+							ValidateFunc: forwardingTypeStringEnum.ValidateFunc,
+						},
+						"https_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"https_forced": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"csv": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"redirect"},
+				ValidateFunc:     validateRedirectSetCSV,
+				DiffSuppressFunc: redirectSetCSVDiffSuppress,
+			},
+			"authoritative": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"batch_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			// Read-only
+			"redirect_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		Create:        RedirectSetCreate,
+		Read:          RedirectSetRead,
+		Update:        RedirectSetUpdate,
+		Delete:        RedirectSetDelete,
+		Importer:      &schema.ResourceImporter{State: redirectSetImportStateFunc},
+		CustomizeDiff: redirectSetCustomizeDiff,
+	}
+}
+
+// redirectSetEntry is a redirect of a redirect set, without the settings
+// that the set leaves as they are.
+type redirectSetEntry struct {
+	Domain         string
+	Path           string
+	Target         string
+	ForwardingType string
+	ForwardingMode string
+	HTTPSEnabled   bool
+	HTTPSForced    bool
+}
+
+// key identifies the redirect of the entry. Domains and paths are not case
+// sensitive.
+func (e redirectSetEntry) key() string {
+	return redirectSetKey(e.Domain, e.Path)
+}
+
+func redirectSetKey(domain, path string) string {
+	return strings.ToLower(domain) + "/" + strings.TrimPrefix(strings.ToLower(path), "/")
+}
+
+func newRedirectSetEntry(domain, path, target, fwType, fwMode string, httpsEnabled, httpsForced bool) (redirectSetEntry, error) {
+	e := redirectSetEntry{
+		Domain:         strings.TrimSpace(domain),
+		Path:           strings.TrimSpace(path),
+		Target:         strings.TrimSpace(target),
+		ForwardingType: strings.TrimSpace(fwType),
+		ForwardingMode: strings.TrimSpace(fwMode),
+		HTTPSEnabled:   httpsEnabled,
+		HTTPSForced:    httpsForced,
+	}
+	if e.ForwardingType == "" {
+		e.ForwardingType = "permanent"
+	}
+	if e.ForwardingMode == "" {
+		e.ForwardingMode = "all"
+	}
+
+	var errs []error
+	_, es := validateDomain(e.Domain, "domain")
+	errs = append(errs, es...)
+	_, es = validatePath(e.Path, "path")
+	errs = append(errs, es...)
+	_, es = validateURL(e.Target, "target")
+	errs = append(errs, es...)
+	if _, err := forwardingTypeStringEnum.Check(e.ForwardingType); err != nil {
+		errs = append(errs, fmt.Errorf("forwarding_type: %s", err))
+	}
+	if _, err := forwardingModeStringEnum.Check(e.ForwardingMode); err != nil {
+		errs = append(errs, fmt.Errorf("forwarding_mode: %s", err))
+	}
+	if len(errs) > 0 {
+		return e, errs[0]
+	}
+	return e, nil
+}
+
+func redirectSetEntryFromConfiguration(r *redirect.Configuration) redirectSetEntry {
+	e := redirectSetEntry{
+		Domain:         r.Domain,
+		Path:           r.Path,
+		Target:         r.Target,
+		ForwardingType: "permanent",
+		ForwardingMode: "all",
+	}
+	if r.ForwardingType != nil {
+		e.ForwardingType = r.ForwardingType.String()
+	}
+	if r.ForwardingMode != nil {
+		e.ForwardingMode = r.ForwardingMode.String()
+	}
+	if r.HttpsEnabled != nil {
+		e.HTTPSEnabled = *r.HttpsEnabled
+	}
+	if r.HttpsForced != nil {
+		e.HTTPSForced = *r.HttpsForced
+	}
+	return e
+}
+
+func hashRedirectSetEntry(v interface{}) int {
+	m := v.(map[string]interface{})
+	return schema.HashString(fmt.Sprintf("%s-%s-%s-%s-%t-%t",
+		redirectSetKey(m["domain"].(string), m["path"].(string)), m["target"], m["forwarding_type"], m["forwarding_mode"],
+		m["https_enabled"], m["https_forced"]))
+}
+
+// parseRedirectSetCSV parses a CSV mapping table. The first row names the
+// columns, which may be in any order.
+func parseRedirectSetCSV(s string) ([]redirectSetEntry, error) {
+	r := csv.NewReader(strings.NewReader(s))
+	r.TrimLeadingSpace = true
+	r.Comment = '#'
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, errors.New("csv has no header row")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if !containsString(redirectSetCSVColumns, h) {
+			return nil, fmt.Errorf("csv has unknown column %q, expecting columns of %s", h, strings.Join(redirectSetCSVColumns, ", "))
+		}
+		columns[h] = i
+	}
+	for _, c := range redirectSetCSVColumns[:3] {
+		if _, ok := columns[c]; !ok {
+			return nil, fmt.Errorf("csv has no %s column", c)
+		}
+	}
+	field := func(row []string, c string) string {
+		if i, ok := columns[c]; ok {
+			return row[i]
+		}
+		return ""
+	}
+
+	var entries []redirectSetEntry
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := r.FieldPos(0)
+		httpsEnabled, err := redirectSetCSVBool(field(row, "https_enabled"))
+		if err != nil {
+			return nil, fmt.Errorf("csv line %d: https_enabled: %s", line, err)
+		}
+		httpsForced, err := redirectSetCSVBool(field(row, "https_forced"))
+		if err != nil {
+			return nil, fmt.Errorf("csv line %d: https_forced: %s", line, err)
+		}
+		e, err := newRedirectSetEntry(field(row, "domain"), field(row, "path"), field(row, "target"),
+			field(row, "forwarding_type"), field(row, "forwarding_mode"), httpsEnabled, httpsForced)
+		if err != nil {
+			return nil, fmt.Errorf("csv line %d: %s", line, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// redirectSetCSVBool parses a boolean column, which is false when empty.
+func redirectSetCSVBool(s string) (bool, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, fmt.Errorf("expecting true or false, got %q", s)
+	}
+	return b, nil
+}
+
+// redirectSetCSV writes entries as a CSV mapping table, sorted by domain
+// and path.
+func redirectSetCSV(entries []redirectSetEntry) string {
+	sorted := sortRedirectSetEntries(entries)
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Write(redirectSetCSVColumns)
+	for _, e := range sorted {
+		w.Write([]string{e.Domain, e.Path, e.Target, e.ForwardingType, e.ForwardingMode,
+			strconv.FormatBool(e.HTTPSEnabled), strconv.FormatBool(e.HTTPSForced)})
+	}
+	w.Flush()
+	return b.String()
+}
+
+func sortRedirectSetEntries(entries []redirectSetEntry) []redirectSetEntry {
+	sorted := append([]redirectSetEntry{}, entries...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].key() < sorted[j].key()
+	})
+	return sorted
+}
+
+func validateRedirectSetCSV(val interface{}, key string) (warns []string, errs []error) {
+	if _, err := parseRedirectSetCSV(val.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%s is invalid: %s", key, err))
+	}
+	return warns, errs
+}
+
+// redirectSetCSVDiffSuppress ignores differences in the order of the rows
+// and columns, quoting and defaulted columns.
+func redirectSetCSVDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	o, err := parseRedirectSetCSV(old)
+	if err != nil {
+		return false
+	}
+	n, err := parseRedirectSetCSV(new)
+	if err != nil {
+		return false
+	}
+	return redirectSetCSV(o) == redirectSetCSV(n)
+}
+
+// redirectSetEntries returns the configured entries of the set, from either
+// the redirect blocks or the csv argument.
+func redirectSetEntries(csv string, redirects []interface{}) ([]redirectSetEntry, error) {
+	if csv != "" {
+		return parseRedirectSetCSV(csv)
+	}
+	entries := make([]redirectSetEntry, 0, len(redirects))
+	for _, v := range redirects {
+		m := v.(map[string]interface{})
+		e, err := newRedirectSetEntry(m["domain"].(string), m["path"].(string), m["target"].(string),
+			m["forwarding_type"].(string), m["forwarding_mode"].(string), m["https_enabled"].(bool), m["https_forced"].(bool))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// checkRedirectSetEntries verifies that every entry is on one of the domains
+// of the set, and that no two entries are for the same domain and path.
+func checkRedirectSetEntries(entries []redirectSetEntry, domains []string) error {
+	seen := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		if !redirectSetHasDomain(domains, e.Domain) {
+			return fmt.Errorf("redirect %s%s is not on one of the domains of the set", e.Domain, e.Path)
+		}
+		if seen[e.key()] {
+			return fmt.Errorf("redirect %s%s is in the set more than once", e.Domain, e.Path)
+		}
+		seen[e.key()] = true
+	}
+	return nil
+}
+
+func redirectSetHasDomain(domains []string, domain string) bool {
+	for _, d := range domains {
+		if strings.EqualFold(d, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+func redirectSetCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("domains") || !d.NewValueKnown("redirect") || !d.NewValueKnown("csv") {
+		return nil
+	}
+	entries, err := redirectSetEntries(d.Get("csv").(string), d.Get("redirect").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+	if err := checkRedirectSetEntries(entries, setToStrings(d.Get("domains").(*schema.Set))); err != nil {
+		return err
+	}
+	if d.Id() != "" && (d.HasChange("domains") || d.HasChange("redirect") || d.HasChange("csv") || d.HasChange("authoritative")) {
+		return d.SetNewComputed("redirect_ids")
+	}
+	return nil
+}
+
+func setToStrings(s *schema.Set) []string {
+	out := make([]string, 0, s.Len())
+	for _, v := range s.List() {
+		out = append(out, v.(string))
+	}
+	sort.Strings(out)
+	return out
+}
+
+// redirectSetChanges works out the redirects to create, update and delete
+// so that the redirects that are managed by the set match the entries. An
+// authoritative set manages every redirect on its domains, and others only
+// the redirects they created or imported: an entry for a redirect that
+// exists but is not managed is an error.
+func redirectSetChanges(
+	entries []redirectSetEntry, existing []*redirect.Configuration, domains []string, managed map[string]bool, authoritative bool,
+) (create []redirectSetEntry, update []*redirect.Configuration, remove []*redirect.Configuration, err error) {
+	current := make(map[string]*redirect.Configuration)
+	foreign := make(map[string]bool)
+	for _, r := range existing {
+		k := redirectSetKey(r.Domain, r.Path)
+		if managed[k] || (authoritative && redirectSetHasDomain(domains, r.Domain)) {
+			current[k] = r
+		} else {
+			foreign[k] = true
+		}
+	}
+
+	wanted := make(map[string]bool, len(entries))
+	for _, e := range sortRedirectSetEntries(entries) {
+		wanted[e.key()] = true
+		r, ok := current[e.key()]
+		if !ok {
+			if foreign[e.key()] {
+				return nil, nil, nil, fmt.Errorf("redirect %s%s already exists and is not managed by the set, import the set or set authoritative to take it over", e.Domain, e.Path)
+			}
+			create = append(create, e)
+			continue
+		}
+		if redirectSetEntryFromConfiguration(r) == (redirectSetEntry{
+			Domain: r.Domain, Path: r.Path, Target: e.Target, ForwardingType: e.ForwardingType, ForwardingMode: e.ForwardingMode,
+			HTTPSEnabled: e.HTTPSEnabled, HTTPSForced: e.HTTPSForced,
+		}) {
+			continue
+		}
+		// Only the fields of the entry are changed, the certificate, query
+		// forwarding and tags of the redirect are left as they are.
+		u := *r
+		u.Target = e.Target
+		fwType, _ := redirect.ParseForwardingType(e.ForwardingType)
+		fwMode, _ := redirect.ParseForwardingMode(e.ForwardingMode)
+		u.ForwardingType = &fwType
+		u.ForwardingMode = &fwMode
+		httpsEnabled, httpsForced := e.HTTPSEnabled, e.HTTPSForced
+		u.HttpsEnabled = &httpsEnabled
+		u.HttpsForced = &httpsForced
+		update = append(update, &u)
+	}
+
+	keys := make([]string, 0, len(current))
+	for k := range current {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !wanted[k] {
+			remove = append(remove, current[k])
+		}
+	}
+	return create, update, remove, nil
+}
+
+// runRedirectSetBatches runs the calls to the API batchSize at a time,
+// stopping after the first batch with errors.
+func runRedirectSetBatches(calls []func() error, batchSize int) error {
+	for start := 0; start < len(calls); start += batchSize {
+		end := start + batchSize
+		if end > len(calls) {
+			end = len(calls)
+		}
+
+		var wg sync.WaitGroup
+		errs := make([]error, end-start)
+		for i, call := range calls[start:end] {
+			wg.Add(1)
+			go func(i int, call func() error) {
+				defer wg.Done()
+				errs[i] = call()
+			}(i, call)
+		}
+		wg.Wait()
+
+		var msgs []string
+		for _, err := range errs {
+			if err != nil {
+				msgs = append(msgs, err.Error())
+			}
+		}
+		if len(msgs) > 0 {
+			return fmt.Errorf("%d of %d redirect changes failed:\n%s", len(msgs), end-start, strings.Join(msgs, "\n"))
+		}
+	}
+	return nil
+}
+
+// applyRedirectSet creates, updates and deletes redirects so that they match
+// the entries of the set. The keys of the redirects it creates or updates
+// are added to managed, also when other changes fail.
+func applyRedirectSet(client *ns1.Client, d *schema.ResourceData, managed map[string]bool) error {
+	entries, err := redirectSetEntries(d.Get("csv").(string), d.Get("redirect").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+	domains := setToStrings(d.Get("domains").(*schema.Set))
+	if err := checkRedirectSetEntries(entries, domains); err != nil {
+		return err
+	}
+
+	existing, resp, err := client.Redirects.List()
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	create, update, remove, err := redirectSetChanges(entries, existing, domains, managed, d.Get("authoritative").(bool))
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] NS1 redirect set (%s): %d to create, %d to update, %d to delete",
+		d.Id(), len(create), len(update), len(remove))
+
+	var mu sync.Mutex
+	done := func(r *redirect.Configuration) {
+		mu.Lock()
+		defer mu.Unlock()
+		managed[redirectSetKey(r.Domain, r.Path)] = true
+	}
+
+	var calls []func() error
+	for _, r := range remove {
+		r := r
+		calls = append(calls, func() error {
+			resp, err := client.Redirects.Delete(*r.ID)
+			if err != nil && err != ns1.ErrRedirectNotFound {
+				return fmt.Errorf("deleting %s%s: %s", r.Domain, r.Path, ConvertToNs1Error(resp, err))
+			}
+			return nil
+		})
+	}
+	for _, r := range update {
+		r := r
+		calls = append(calls, func() error {
+			if _, resp, err := client.Redirects.Update(r); err != nil {
+				return fmt.Errorf("updating %s%s: %s", r.Domain, r.Path, ConvertToNs1Error(resp, err))
+			}
+			done(r)
+			return nil
+		})
+	}
+	for _, e := range create {
+		fwType, _ := redirect.ParseForwardingType(e.ForwardingType)
+		fwMode, _ := redirect.ParseForwardingMode(e.ForwardingMode)
+		httpsEnabled, httpsForced, f := e.HTTPSEnabled, e.HTTPSForced, false
+		r := redirect.NewConfiguration(e.Domain, e.Path, e.Target, []string{}, &fwMode, &fwType, &httpsEnabled, &httpsForced, &f)
+		calls = append(calls, func() error {
+			if _, resp, err := client.Redirects.Create(r); err != nil {
+				return fmt.Errorf("creating %s%s: %s", r.Domain, r.Path, ConvertToNs1Error(resp, err))
+			}
+			done(r)
+			return nil
+		})
+	}
+	return runRedirectSetBatches(calls, d.Get("batch_size").(int))
+}
+
+// redirectSetManaged returns the keys of the redirects in the state of the
+// set.
+func redirectSetManaged(d *schema.ResourceData) map[string]bool {
+	o, _ := d.GetChange("redirect_ids")
+	managed := make(map[string]bool)
+	for k := range o.(map[string]interface{}) {
+		managed[k] = true
+	}
+	return managed
+}
+
+// RedirectSetCreate creates the redirects of a redirect set
+func RedirectSetCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	d.SetId(strings.Join(setToStrings(d.Get("domains").(*schema.Set)), ","))
+
+	// The redirects that were created before an error are kept in the state.
+	managed := make(map[string]bool)
+	err := applyRedirectSet(client, d, managed)
+	if err != nil && len(managed) == 0 {
+		d.SetId("")
+		return err
+	}
+	if readErr := redirectSetRead(d, meta, managed); readErr != nil {
+		return readErr
+	}
+	return err
+}
+
+// RedirectSetRead reads the redirects of a redirect set
+func RedirectSetRead(d *schema.ResourceData, meta interface{}) error {
+	return redirectSetRead(d, meta, redirectSetManaged(d))
+}
+
+// redirectSetRead reads the managed redirects.
+func redirectSetRead(d *schema.ResourceData, meta interface{}, managed map[string]bool) error {
+	client := meta.(*ns1.Client)
+
+	existing, resp, err := client.Redirects.List()
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	return redirectSetToResourceData(d, existing, managed)
+}
+
+// RedirectSetUpdate applies the changes to the entries of a redirect set.
+// Redirects that were in the set are deleted when they are no longer in it,
+// even if their domain is no longer one of the domains of the set.
+func RedirectSetUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	managed := redirectSetManaged(d)
+
+	err := applyRedirectSet(client, d, managed)
+	if readErr := redirectSetRead(d, meta, managed); readErr != nil {
+		return readErr
+	}
+	return err
+}
+
+// RedirectSetDelete deletes the redirects of a redirect set
+func RedirectSetDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+
+	var calls []func() error
+	for k, id := range d.Get("redirect_ids").(map[string]interface{}) {
+		k, id := k, id.(string)
+		calls = append(calls, func() error {
+			resp, err := client.Redirects.Delete(id)
+			if err != nil && err != ns1.ErrRedirectNotFound {
+				return fmt.Errorf("deleting %s: %s", k, ConvertToNs1Error(resp, err))
+			}
+			return nil
+		})
+	}
+	if err := runRedirectSetBatches(calls, d.Get("batch_size").(int)); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+// redirectSetImportStateFunc imports the redirects on a comma separated list
+// of domains as redirect blocks. The imported redirects are managed by the
+// set from then on.
+func redirectSetImportStateFunc(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*ns1.Client)
+	var domains []string
+	for _, domain := range strings.Split(d.Id(), ",") {
+		domains = append(domains, strings.TrimSpace(domain))
+	}
+
+	existing, resp, err := client.Redirects.List()
+	if err != nil {
+		return nil, ConvertToNs1Error(resp, err)
+	}
+	ids := make(map[string]interface{})
+	for _, r := range existing {
+		if r.ID != nil && redirectSetHasDomain(domains, r.Domain) {
+			ids[redirectSetKey(r.Domain, r.Path)] = *r.ID
+		}
+	}
+
+	d.Set("domains", domains)
+	d.Set("redirect_ids", ids)
+	d.Set("authoritative", false)
+	d.Set("batch_size", 10)
+	return []*schema.ResourceData{d}, nil
+}
+
+// redirectSetToResourceData sets the redirects of the set: the redirects of
+// managed, and every redirect on the domains of an authoritative set.
+func redirectSetToResourceData(d *schema.ResourceData, existing []*redirect.Configuration, managed map[string]bool) error {
+	domains := setToStrings(d.Get("domains").(*schema.Set))
+	authoritative := d.Get("authoritative").(bool)
+	var entries []redirectSetEntry
+	ids := make(map[string]interface{})
+	for _, r := range existing {
+		if r.ID == nil {
+			continue
+		}
+		k := redirectSetKey(r.Domain, r.Path)
+		if !managed[k] && !(authoritative && redirectSetHasDomain(domains, r.Domain)) {
+			continue
+		}
+		e := redirectSetEntryFromConfiguration(r)
+		entries = append(entries, e)
+		ids[e.key()] = *r.ID
+	}
+
+	if d.Get("csv").(string) != "" {
+		d.Set("csv", redirectSetCSV(entries))
+	} else {
+		redirects := make([]interface{}, 0, len(entries))
+		for _, e := range sortRedirectSetEntries(entries) {
+			redirects = append(redirects, map[string]interface{}{
+				"domain":          e.Domain,
+				"path":            e.Path,
+				"target":          e.Target,
+				"forwarding_type": e.ForwardingType,
+				"forwarding_mode": e.ForwardingMode,
+				"https_enabled":   e.HTTPSEnabled,
+				"https_forced":    e.HTTPSForced,
+			})
+		}
+		if err := d.Set("redirect", redirects); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting redirects for redirect set %s, error: %#v", d.Id(), err)
+		}
+	}
+	if err := d.Set("redirect_ids", ids); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting redirect IDs for redirect set %s, error: %#v", d.Id(), err)
+	}
+	return nil
+}
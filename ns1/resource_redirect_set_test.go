@@ -0,0 +1,289 @@
+package ns1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/redirect"
+)
+
+func TestAccRedirectSet_basic(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	domain := fmt.Sprintf("test.terraform-test-%s.io", rString)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccRedirectPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRedirectSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRedirectSetBlocks(rString),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ns1_redirect_set.it", "redirect.#", "2"),
+					resource.TestCheckResourceAttr("ns1_redirect_set.it", "redirect_ids.%", "2"),
+					resource.TestCheckResourceAttrSet("ns1_redirect_set.it", "redirect_ids."+domain+"/a"),
+					resource.TestCheckResourceAttrSet("ns1_redirect_set.it", "redirect_ids."+domain+"/b"),
+				),
+			},
+			{
+				ResourceName:      "ns1_redirect_set.it",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccRedirectSetCSV(rString),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ns1_redirect_set.it", "redirect_ids.%", "2"),
+					resource.TestCheckResourceAttrSet("ns1_redirect_set.it", "redirect_ids."+domain+"/b"),
+					resource.TestCheckResourceAttrSet("ns1_redirect_set.it", "redirect_ids."+domain+"/c"),
+					resource.TestCheckNoResourceAttr("ns1_redirect_set.it", "redirect_ids."+domain+"/a"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseRedirectSetCSV(t *testing.T) {
+	entries, err := parseRedirectSetCSV(`path, domain, target, forwarding_type
+# vanity redirects
+/a,www.example.com,https://example.com/a,
+/b, www.example.com, https://example.com/b, temporary
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []redirectSetEntry{
+		{"www.example.com", "/a", "https://example.com/a", "permanent", "all", false, false},
+		{"www.example.com", "/b", "https://example.com/b", "temporary", "all", false, false},
+	}, entries)
+
+	_, err = parseRedirectSetCSV("")
+	assert.EqualError(t, err, "csv has no header row")
+	_, err = parseRedirectSetCSV("domain,path\n")
+	assert.EqualError(t, err, "csv has no target column")
+	_, err = parseRedirectSetCSV("domain,path,target,tags\n")
+	assert.Error(t, err)
+	_, err = parseRedirectSetCSV("domain,path,target\nwww.example.com,/a,https://example.com/a\nwww.example.com,/b,https://example.com/b,extra\n")
+	assert.Error(t, err)
+	_, err = parseRedirectSetCSV("domain,path,target,forwarding_mode\nwww.example.com,/a,https://example.com/a,most\n")
+	assert.EqualError(t, err, `csv line 2: forwarding_mode: expecting one of "all", "capture", "none"; got "most"`)
+	_, err = parseRedirectSetCSV("domain,path,target,https_forced\nwww.example.com,/a,https://example.com/a,maybe\n")
+	assert.EqualError(t, err, `csv line 2: https_forced: expecting true or false, got "maybe"`)
+
+	entries, err = parseRedirectSetCSV("domain,path,target,https_enabled,https_forced\nwww.example.com,/a,https://example.com/a,true,\n")
+	require.NoError(t, err)
+	assert.Equal(t, []redirectSetEntry{
+		{"www.example.com", "/a", "https://example.com/a", "permanent", "all", true, false},
+	}, entries)
+}
+
+func TestRedirectSetCSVDiffSuppress(t *testing.T) {
+	old := redirectSetCSV([]redirectSetEntry{
+		{"www.example.com", "/b", "https://example.com/b", "temporary", "all", false, false},
+		{"www.example.com", "/a", "https://example.com/a", "permanent", "all", false, false},
+	})
+	assert.Equal(t, "domain,path,target,forwarding_type,forwarding_mode,https_enabled,https_forced\n"+
+		"www.example.com,/a,https://example.com/a,permanent,all,false,false\n"+
+		"www.example.com,/b,https://example.com/b,temporary,all,false,false\n", old)
+
+	assert.True(t, redirectSetCSVDiffSuppress("csv", old, `target,domain,path,forwarding_type
+https://example.com/b,www.example.com,/b,temporary
+"https://example.com/a",www.example.com,/a,
+`, nil))
+	assert.False(t, redirectSetCSVDiffSuppress("csv", old, `domain,path,target
+www.example.com,/a,https://example.com/a
+www.example.com,/b,https://example.com/b
+`, nil))
+	assert.False(t, redirectSetCSVDiffSuppress("csv", old, "domain,path\n", nil))
+}
+
+func TestRedirectSetChanges(t *testing.T) {
+	id := func(s string) *string { return &s }
+	permanent, temporary := redirect.Permanent, redirect.Temporary
+	all := redirect.All
+	existing := []*redirect.Configuration{
+		{ID: id("1"), Domain: "www.example.com", Path: "/same", Target: "https://example.com/same", ForwardingType: &permanent, ForwardingMode: &all},
+		{ID: id("2"), Domain: "www.example.com", Path: "/changed", Target: "https://example.com/old", ForwardingType: &permanent, ForwardingMode: &all, Tags: []string{"kept"}},
+		{ID: id("3"), Domain: "WWW.example.com", Path: "/extra", Target: "https://example.com/extra"},
+		{ID: id("4"), Domain: "other.example.com", Path: "/unmanaged", Target: "https://example.com/unmanaged"},
+		{ID: id("5"), Domain: "old.example.com", Path: "/managed", Target: "https://example.com/managed"},
+	}
+	entries := []redirectSetEntry{
+		{"www.example.com", "/SAME", "https://example.com/same", "permanent", "all", false, false},
+		{"www.example.com", "/changed", "https://example.com/new", "temporary", "all", false, false},
+		{"www.example.com", "/new", "https://example.com/new", "permanent", "all", false, false},
+	}
+
+	create, update, remove, err := redirectSetChanges(entries, existing, []string{"www.example.com"}, map[string]bool{"old.example.com/managed": true}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []redirectSetEntry{entries[2]}, create)
+	require.Len(t, update, 1)
+	assert.Equal(t, "2", *update[0].ID)
+	assert.Equal(t, "https://example.com/new", update[0].Target)
+	assert.Equal(t, &temporary, update[0].ForwardingType)
+	assert.Equal(t, []string{"kept"}, update[0].Tags)
+	assert.Equal(t, "https://example.com/old", existing[1].Target)
+	require.Len(t, remove, 2)
+	assert.Equal(t, "5", *remove[0].ID)
+	assert.Equal(t, "3", *remove[1].ID)
+
+	// A set that is not authoritative leaves the redirects it does not manage.
+	managed := map[string]bool{"www.example.com/same": true, "www.example.com/changed": true, "old.example.com/managed": true}
+	entries[0].HTTPSEnabled = true
+	create, update, remove, err = redirectSetChanges(entries, existing, []string{"www.example.com"}, managed, false)
+	require.NoError(t, err)
+	assert.Equal(t, []redirectSetEntry{entries[2]}, create)
+	require.Len(t, update, 2)
+	assert.Equal(t, "2", *update[0].ID)
+	assert.Equal(t, "1", *update[1].ID)
+	assert.True(t, *update[1].HttpsEnabled)
+	assert.False(t, *update[1].HttpsForced)
+	require.Len(t, remove, 1)
+	assert.Equal(t, "5", *remove[0].ID)
+
+	_, _, _, err = redirectSetChanges(append(entries, redirectSetEntry{
+		"www.example.com", "/extra", "https://example.com/extra", "permanent", "all", false, false,
+	}), existing, []string{"www.example.com"}, managed, false)
+	assert.EqualError(t, err, "redirect www.example.com/extra already exists and is not managed by the set, import the set or set authoritative to take it over")
+}
+
+func TestRedirectSetCreate_foreignRedirect(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.NoError(t, err)
+	defer mock.Shutdown()
+	client := ns1.NewClient(doer, ns1.SetAPIKey("apikey"))
+	client.Endpoint, _ = url.Parse("https://" + mock.Address + "/v1/")
+
+	foreign := "foreign1"
+	require.NoError(t, mock.AddRedirectListTestCase(nil, nil, &redirect.ConfigurationList{
+		Results: []*redirect.Configuration{{ID: &foreign, Domain: "example.com", Path: "/a", Target: "https://example.net"}},
+	}))
+
+	d := schema.TestResourceDataRaw(t, redirectSetResource().Schema, map[string]interface{}{
+		"domains": []interface{}{"example.com"},
+		"redirect": []interface{}{
+			map[string]interface{}{"domain": "example.com", "path": "/a", "target": "https://example.com/a"},
+		},
+	})
+	err = RedirectSetCreate(d, client)
+	assert.ErrorContains(t, err, "redirect example.com/a already exists and is not managed by the set")
+	assert.Empty(t, d.Id())
+	assert.Empty(t, d.Get("redirect_ids"))
+}
+
+func TestRedirectSetValidation(t *testing.T) {
+	config := func(domains []interface{}, redirects ...interface{}) map[string]interface{} {
+		return map[string]interface{}{"domains": domains, "redirect": redirects}
+	}
+	entry := func(domain, path string) map[string]interface{} {
+		return map[string]interface{}{"domain": domain, "path": path, "target": "https://example.com/t"}
+	}
+	domains := []interface{}{"www.example.com"}
+
+	r := redirectSetResource()
+	state := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"domains": domains})
+	state.SetId("www.example.com")
+
+	_, err := r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(config(domains, entry("www.example.com", "/a"))), nil)
+	assert.NoError(t, err)
+	_, err = r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(config(domains, entry("www.example.org", "/a"))), nil)
+	assert.Error(t, err)
+	dup := entry("WWW.example.com", "/A")
+	dup["target"] = "https://example.com/other"
+	_, err = r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(config(domains, entry("www.example.com", "/a"), dup)), nil)
+	assert.Error(t, err)
+
+	diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"domains":  domains,
+		"redirect": []interface{}{entry("www.example.com", "/a")},
+		"csv":      "domain,path,target\n",
+	}))
+	assert.True(t, diags.HasError())
+}
+
+func TestRunRedirectSetBatches(t *testing.T) {
+	var calls int32
+	ok := func() error { atomic.AddInt32(&calls, 1); return nil }
+	fail := func() error { atomic.AddInt32(&calls, 1); return errors.New("failed") }
+
+	assert.NoError(t, runRedirectSetBatches([]func() error{ok, ok, ok, ok, ok}, 2))
+	assert.Equal(t, int32(5), calls)
+
+	calls = 0
+	err := runRedirectSetBatches([]func() error{ok, ok, fail, ok, ok}, 2)
+	assert.EqualError(t, err, "1 of 2 redirect changes failed:\nfailed")
+	assert.Equal(t, int32(4), calls)
+}
+
+func testAccCheckRedirectSetDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ns1.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ns1_redirect_set" {
+			continue
+		}
+		for k, id := range rs.Primary.Attributes {
+			if !strings.HasPrefix(k, "redirect_ids.") || k == "redirect_ids.%" {
+				continue
+			}
+			if _, _, err := client.Redirects.Get(id); err != ns1.ErrRedirectNotFound {
+				return fmt.Errorf("redirect %s still exists: %#v", id, err)
+			}
+		}
+	}
+	return nil
+}
+
+func testAccRedirectSetBlocks(rString string) string {
+	return fmt.Sprintf(`
+resource "ns1_zone" "test" {
+  zone = "terraform-test-%s.io"
+}
+
+resource "ns1_redirect_set" "it" {
+  domains = ["test.${ns1_zone.test.zone}"]
+
+  redirect {
+    domain = "test.${ns1_zone.test.zone}"
+    path   = "/a"
+    target = "https://url.com/a"
+  }
+
+  redirect {
+    domain          = "test.${ns1_zone.test.zone}"
+    path            = "/b"
+    target          = "https://url.com/b"
+    forwarding_type = "temporary"
+  }
+}
+`, rString)
+}
+
+func testAccRedirectSetCSV(rString string) string {
+	return fmt.Sprintf(`
+resource "ns1_zone" "test" {
+  zone = "terraform-test-%s.io"
+}
+
+resource "ns1_redirect_set" "it" {
+  domains = ["test.${ns1_zone.test.zone}"]
+
+  csv = <<-EOT
+    domain,path,target,forwarding_type
+    test.${ns1_zone.test.zone},/b,https://url.com/b,temporary
+    test.${ns1_zone.test.zone},/c,https://url.com/c,
+  EOT
+}
+`, rString)
+}
@@ -3,13 +3,20 @@ package ns1
 import (
 	"fmt"
 	"log"
+	"net/url"
+	"regexp"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/redirect"
 )
@@ -210,6 +217,93 @@ func TestAccRedirectConfig_remoteChanges(t *testing.T) {
 	})
 }
 
+func TestAccRedirectConfig_missingCertificate(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccRedirectPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRedirectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "ns1_redirect" "it" {
+  certificate_id = "00000000-0000-0000-0000-000000000000"
+  domain         = "test.${ns1_zone.test.zone}"
+  path           = "/from/path/*"
+  target         = "https://url.com/target/path"
+}
+
+resource "ns1_zone" "test" {
+  zone = "terraform-test-%s.io"
+}
+`, rString),
+				ExpectError: regexp.MustCompile(`redirect certificate .* not found`),
+			},
+		},
+	})
+}
+
+func TestRedirectCertIssued(t *testing.T) {
+	yes, no := true, false
+	pem, empty, failed := "-----BEGIN CERTIFICATE-----", "", "failed renewing certificate"
+
+	issued, err := redirectCertIssued(&redirect.Certificate{Certificate: &pem, Processing: &no})
+	assert.NoError(t, err)
+	assert.True(t, issued)
+
+	issued, err = redirectCertIssued(&redirect.Certificate{Certificate: &pem, Processing: &yes})
+	assert.NoError(t, err)
+	assert.False(t, issued)
+
+	issued, err = redirectCertIssued(&redirect.Certificate{Processing: &no})
+	assert.NoError(t, err)
+	assert.False(t, issued)
+
+	issued, err = redirectCertIssued(&redirect.Certificate{Certificate: &pem, Errors: &empty})
+	assert.NoError(t, err)
+	assert.True(t, issued)
+
+	_, err = redirectCertIssued(&redirect.Certificate{Domain: "www.example.com", Certificate: &pem, Errors: &failed})
+	assert.EqualError(t, err, "redirect certificate for www.example.com failed: failed renewing certificate")
+}
+
+func TestRedirectCertRenewalDue(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	day := int64(24 * 3600)
+
+	assert.False(t, redirectCertRenewalDue(now.Unix()+10*day, 0, now))
+	assert.False(t, redirectCertRenewalDue(0, 30, now))
+	assert.False(t, redirectCertRenewalDue(now.Unix()+31*day, 30, now))
+	assert.True(t, redirectCertRenewalDue(now.Unix()+29*day, 30, now))
+	assert.True(t, redirectCertRenewalDue(now.Unix()-day, 30, now))
+}
+
+func TestSetRedirectCertificate_notIssued(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.NoError(t, err)
+	defer mock.Shutdown()
+	client := ns1.NewClient(doer, ns1.SetAPIKey("apikey"))
+	client.Endpoint, _ = url.Parse("https://" + mock.Address + "/v1/")
+
+	yes := true
+	require.NoError(t, mock.AddRedirectCertificateGetTestCase("cert", nil, nil, &redirect.Certificate{
+		Domain: "www.example.com", Processing: &yes,
+	}))
+
+	d := schema.TestResourceDataRaw(t, redirectConfigResource().Schema, map[string]interface{}{
+		"domain":         "www.example.com",
+		"path":           "/a",
+		"target":         "https://example.com",
+		"certificate_id": "cert",
+	})
+	r := &redirect.Configuration{Domain: "www.example.com"}
+	err = setRedirectCertificate(client, d, r, 0)
+	assert.ErrorContains(t, err, "HTTPS can't be enabled for www.example.com: timed out")
+	assert.Nil(t, r.HttpsEnabled)
+	assert.Nil(t, r.CertificateID)
+}
+
 func testAccRedirectBasic(rString string) string {
 	return fmt.Sprintf(`
 resource "ns1_redirect" "it" {
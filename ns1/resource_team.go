@@ -3,6 +3,7 @@ package ns1
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -25,17 +26,15 @@ func teamResource() *schema.Resource {
 						Type:     schema.TypeString,
 						Required: true,
 					},
-					"values": {
-						Type:     schema.TypeSet,
-						Elem:     &schema.Schema{Type: schema.TypeString},
-						Required: true,
-					},
+					"values": ipWhitelistSchema(true),
 				},
 			},
+			Set: hashTeamIPWhitelist,
 		},
 	}
 
 	s = addPermsSchema(s)
+	s = addRecordsACLSchema(s)
 
 	return &schema.Resource{
 		Schema:        s,
@@ -44,18 +43,30 @@ func teamResource() *schema.Resource {
 		Update:        TeamUpdate,
 		Delete:        TeamDelete,
 		Importer:      &schema.ResourceImporter{State: teamImportStateFunc},
-		SchemaVersion: 1,
+		CustomizeDiff: recordsACLCustomizeDiff,
+		SchemaVersion: 2,
 		StateUpgraders: []schema.StateUpgrader{
 			{
 				Type:    teamResourceV0().CoreConfigSchema().ImpliedType(),
 				Upgrade: permissionInstanceStateUpgradeV0,
 				Version: 0,
 			},
+			{
+				Type:    teamResourceV1().CoreConfigSchema().ImpliedType(),
+				Upgrade: permissionInstanceStateUpgradeV1,
+				Version: 1,
+			},
 		},
 	}
 }
 
-func teamToResourceData(d *schema.ResourceData, t *account.Team) error {
+// hashTeamIPWhitelist hashes a named whitelist by its normalized values.
+func hashTeamIPWhitelist(v interface{}) int {
+	m := v.(map[string]interface{})
+	return schema.HashString(fmt.Sprintf("%s-%s", m["name"], strings.Join(schemaToIPWhitelist(m["values"]), ",")))
+}
+
+func teamToResourceData(d *schema.ResourceData, t *account.Team, client *ns1.Client) error {
 	d.SetId(t.ID)
 	d.Set("name", t.Name)
 
@@ -72,7 +83,7 @@ func teamToResourceData(d *schema.ResourceData, t *account.Team) error {
 
 	permissionsToResourceData(d, t.Permissions)
 
-	return nil
+	return recordsACLMatchesToResourceData(d, client)
 }
 
 func resourceDataToTeam(t *account.Team, d *schema.ResourceData) error {
@@ -85,15 +96,9 @@ func resourceDataToTeam(t *account.Team, d *schema.ResourceData) error {
 	for _, v := range ipWhitelistsRaw.List() {
 		ipWhitelistRaw := v.(map[string]interface{})
 
-		valsRaw := ipWhitelistRaw["values"].(*schema.Set)
-		vals := make([]string, 0, valsRaw.Len())
-		for _, vv := range valsRaw.List() {
-			vals = append(vals, vv.(string))
-		}
-
 		t.IPWhitelist = append(t.IPWhitelist, account.IPWhitelist{
 			Name:   ipWhitelistRaw["name"].(string),
-			Values: vals,
+			Values: schemaToIPWhitelist(ipWhitelistRaw["values"]),
 		})
 	}
 
@@ -108,11 +113,14 @@ func TeamCreate(d *schema.ResourceData, meta interface{}) error {
 	if err := resourceDataToTeam(&t, d); err != nil {
 		return err
 	}
+	if err := verifyRecordsACL(d, client); err != nil {
+		return err
+	}
 	if resp, err := client.Teams.Create(&t); err != nil {
 		return ConvertToNs1Error(resp, err)
 	}
 	// workaround INBOX-2226 - send a GET to refresh object
-	_ = teamToResourceData(d, &t)
+	_ = teamToResourceData(d, &t, client)
 	return TeamRead(d, meta)
 }
 
@@ -129,7 +137,7 @@ func TeamRead(d *schema.ResourceData, meta interface{}) error {
 
 		return ConvertToNs1Error(resp, err)
 	}
-	return teamToResourceData(d, t)
+	return teamToResourceData(d, t, client)
 }
 
 // TeamDelete deletes the given team from ns1
@@ -149,14 +157,16 @@ func TeamUpdate(d *schema.ResourceData, meta interface{}) error {
 	if err := resourceDataToTeam(&t, d); err != nil {
 		return err
 	}
+	if err := verifyRecordsACL(d, client); err != nil {
+		return err
+	}
 	if resp, err := client.Teams.Update(&t); err != nil {
 		return ConvertToNs1Error(resp, err)
 	}
 
-	// @TODO - when a teams permissions are updated, all users and keys assigned to that team
-	// should have their Terraform state refreshed, there is not a particularly nice way to implement this
-	// because teams don't have a concept of what users and keys are assigned to them, only the other way around.
-	return teamToResourceData(d, &t)
+	// Users and keys on the team pick up the change in their effective_permissions
+	// when they are next read; teams don't know which users and keys are assigned to them.
+	return teamToResourceData(d, &t, client)
 }
 
 func teamImportStateFunc(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -0,0 +1,143 @@
+package ns1
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+func teamMembersResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"users": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"apikeys": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+		Create:   TeamMembersCreate,
+		Read:     TeamMembersRead,
+		Update:   TeamMembersUpdate,
+		Delete:   TeamMembersDelete,
+		Importer: &schema.ResourceImporter{State: teamMembersImportStateFunc},
+	}
+}
+
+// applyTeamMembers makes the users and API keys of the resource the only
+// members of the team.
+func applyTeamMembers(d *schema.ResourceData, client *ns1.Client) error {
+	teamMembershipMu.Lock()
+	defer teamMembershipMu.Unlock()
+
+	teamID := d.Get("team_id").(string)
+	users := schemaToStrings(d.Get("users").(*schema.Set).List())
+	apikeys := schemaToStrings(d.Get("apikeys").(*schema.Set).List())
+
+	currentUsers, currentKeys, err := teamMembers(client, teamID)
+	if err != nil {
+		return err
+	}
+	for _, u := range subtractStrings(currentUsers, users) {
+		if err := setUserTeam(client, u, teamID, false); err != nil {
+			return err
+		}
+	}
+	for _, k := range subtractStrings(currentKeys, apikeys) {
+		if err := setAPIKeyTeam(client, k, teamID, false); err != nil {
+			return err
+		}
+	}
+	for _, u := range users {
+		if err := setUserTeam(client, u, teamID, true); err != nil {
+			return err
+		}
+	}
+	for _, k := range apikeys {
+		if err := setAPIKeyTeam(client, k, teamID, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TeamMembersCreate sets the members of a team
+func TeamMembersCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	if err := applyTeamMembers(d, client); err != nil {
+		return err
+	}
+	d.SetId(d.Get("team_id").(string))
+	return TeamMembersRead(d, meta)
+}
+
+// TeamMembersRead reads every member of a team
+func TeamMembersRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	teamID := d.Get("team_id").(string)
+	if _, resp, err := client.Teams.Get(teamID); err != nil {
+		if err == ns1.ErrTeamMissing {
+			log.Printf("[DEBUG] NS1 team (%s) not found", teamID)
+			d.SetId("")
+			return nil
+		}
+		return ConvertToNs1Error(resp, err)
+	}
+
+	users, apikeys, err := teamMembers(client, teamID)
+	if err != nil {
+		return err
+	}
+	d.Set("users", users)
+	d.Set("apikeys", apikeys)
+	return nil
+}
+
+// TeamMembersUpdate adds and removes members of a team
+func TeamMembersUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	if err := applyTeamMembers(d, client); err != nil {
+		return err
+	}
+	return TeamMembersRead(d, meta)
+}
+
+// TeamMembersDelete removes the users and API keys of the resource from the
+// team
+func TeamMembersDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	teamMembershipMu.Lock()
+	defer teamMembershipMu.Unlock()
+
+	teamID := d.Get("team_id").(string)
+	for _, u := range schemaToStrings(d.Get("users").(*schema.Set).List()) {
+		if err := setUserTeam(client, u, teamID, false); err != nil {
+			return err
+		}
+	}
+	for _, k := range schemaToStrings(d.Get("apikeys").(*schema.Set).List()) {
+		if err := setAPIKeyTeam(client, k, teamID, false); err != nil {
+			return err
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+func teamMembersImportStateFunc(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("team_id", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
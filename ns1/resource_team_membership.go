@@ -0,0 +1,252 @@
+package ns1
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+// teamMembershipMu serializes the read-modify-write of the teams of users and
+// API keys, which can be members of several ns1_team_membership at once.
+var teamMembershipMu sync.Mutex
+
+func teamMembershipResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"user", "apikey"},
+			},
+			"apikey": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"user", "apikey"},
+			},
+		},
+		Create:   TeamMembershipCreate,
+		Read:     TeamMembershipRead,
+		Delete:   TeamMembershipDelete,
+		Importer: &schema.ResourceImporter{State: teamMembershipImportStateFunc},
+	}
+}
+
+// updateMemberTeams sets the teams of a user or API key. Only the teams are
+// sent: NS1 reports the permissions of a member of teams merged with those
+// of its teams, so writing back the whole user or key would give it the
+// permissions of its teams for good.
+func updateMemberTeams(client *ns1.Client, path string, teamIDs []string) error {
+	req, err := client.NewRequest("POST", path, map[string]interface{}{"teams": teamIDs})
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req, nil)
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	return nil
+}
+
+// setUserTeam adds a user to a team, or removes it from the team.
+func setUserTeam(client *ns1.Client, username, teamID string, member bool) error {
+	u, resp, err := client.Users.Get(username)
+	if err != nil {
+		if err == ns1.ErrUserMissing && !member {
+			log.Printf("[DEBUG] NS1 user (%s) not found", username)
+			return nil
+		}
+		return ConvertToNs1Error(resp, err)
+	}
+	if containsString(u.TeamIDs, teamID) == member {
+		return nil
+	}
+
+	teams := subtractStrings(u.TeamIDs, []string{teamID})
+	if member {
+		teams = append(teams, teamID)
+	}
+	if err := updateMemberTeams(client, fmt.Sprintf("account/users/%s", username), teams); err != nil {
+		return err
+	}
+
+	// The user is read back to check the change took.
+	u, resp, err = client.Users.Get(username)
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	if containsString(u.TeamIDs, teamID) != member {
+		return fmt.Errorf("teams of user %s were not updated", username)
+	}
+	return nil
+}
+
+// setAPIKeyTeam adds an API key to a team, or removes it from the team.
+func setAPIKeyTeam(client *ns1.Client, id, teamID string, member bool) error {
+	k, resp, err := client.APIKeys.Get(id)
+	if err != nil {
+		if err == ns1.ErrKeyMissing && !member {
+			log.Printf("[DEBUG] NS1 API key (%s) not found", id)
+			return nil
+		}
+		return ConvertToNs1Error(resp, err)
+	}
+	if containsString(k.TeamIDs, teamID) == member {
+		return nil
+	}
+
+	teams := subtractStrings(k.TeamIDs, []string{teamID})
+	if member {
+		teams = append(teams, teamID)
+	}
+	if err := updateMemberTeams(client, fmt.Sprintf("account/apikeys/%s", id), teams); err != nil {
+		return err
+	}
+
+	k, resp, err = client.APIKeys.Get(id)
+	if err != nil {
+		return ConvertToNs1Error(resp, err)
+	}
+	if containsString(k.TeamIDs, teamID) != member {
+		return fmt.Errorf("teams of API key %s were not updated", id)
+	}
+	return nil
+}
+
+// teamMembers returns the users and API keys on a team.
+func teamMembers(client *ns1.Client, teamID string) (users, apikeys []string, err error) {
+	us, resp, err := client.Users.List()
+	if err != nil {
+		return nil, nil, ConvertToNs1Error(resp, err)
+	}
+	for _, u := range us {
+		if containsString(u.TeamIDs, teamID) {
+			users = append(users, u.Username)
+		}
+	}
+
+	ks, resp, err := client.APIKeys.List()
+	if err != nil {
+		return nil, nil, ConvertToNs1Error(resp, err)
+	}
+	for _, k := range ks {
+		if containsString(k.TeamIDs, teamID) {
+			apikeys = append(apikeys, k.ID)
+		}
+	}
+
+	sort.Strings(users)
+	sort.Strings(apikeys)
+	return users, apikeys, nil
+}
+
+// teamMembershipMember returns whether the membership is of a user or an
+// API key, and the username or ID of the member.
+func teamMembershipMember(d *schema.ResourceData) (string, string) {
+	if u := d.Get("user").(string); u != "" {
+		return "user", u
+	}
+	return "apikey", d.Get("apikey").(string)
+}
+
+// TeamMembershipCreate adds a user or API key to a team
+func TeamMembershipCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	teamMembershipMu.Lock()
+	defer teamMembershipMu.Unlock()
+
+	teamID := d.Get("team_id").(string)
+	kind, member := teamMembershipMember(d)
+	var err error
+	if kind == "user" {
+		err = setUserTeam(client, member, teamID, true)
+	} else {
+		err = setAPIKeyTeam(client, member, teamID, true)
+	}
+	if err != nil {
+		return err
+	}
+	d.SetId(fmt.Sprintf("%s/%s/%s", teamID, kind, member))
+	return nil
+}
+
+// TeamMembershipRead checks the user or API key is still on the team
+func TeamMembershipRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	teamID := d.Get("team_id").(string)
+	kind, member := teamMembershipMember(d)
+
+	var teams []string
+	if kind == "user" {
+		u, resp, err := client.Users.Get(member)
+		if err != nil {
+			if err == ns1.ErrUserMissing {
+				log.Printf("[DEBUG] NS1 user (%s) not found", member)
+				d.SetId("")
+				return nil
+			}
+			return ConvertToNs1Error(resp, err)
+		}
+		teams = u.TeamIDs
+	} else {
+		k, resp, err := client.APIKeys.Get(member)
+		if err != nil {
+			if err == ns1.ErrKeyMissing {
+				log.Printf("[DEBUG] NS1 API key (%s) not found", member)
+				d.SetId("")
+				return nil
+			}
+			return ConvertToNs1Error(resp, err)
+		}
+		teams = k.TeamIDs
+	}
+	if !containsString(teams, teamID) {
+		log.Printf("[DEBUG] NS1 %s (%s) is not on team (%s)", kind, member, teamID)
+		d.SetId("")
+	}
+	return nil
+}
+
+// TeamMembershipDelete removes the user or API key from the team
+func TeamMembershipDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	teamMembershipMu.Lock()
+	defer teamMembershipMu.Unlock()
+
+	teamID := d.Get("team_id").(string)
+	kind, member := teamMembershipMember(d)
+	var err error
+	if kind == "user" {
+		err = setUserTeam(client, member, teamID, false)
+	} else {
+		err = setAPIKeyTeam(client, member, teamID, false)
+	}
+	if err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+func teamMembershipImportStateFunc(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || (parts[1] != "user" && parts[1] != "apikey") {
+		return nil, fmt.Errorf("invalid team membership specifier. Expecting \"team_id/user/username\" or \"team_id/apikey/apikey_id\", got %q", d.Id())
+	}
+
+	d.Set("team_id", parts[0])
+	d.Set(parts[1], parts[2])
+	return []*schema.ResourceData{d}, nil
+}
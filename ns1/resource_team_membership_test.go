@@ -0,0 +1,241 @@
+package ns1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+func TestAccTeamMembership_basic(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTeamDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamMembership(rString, `[ns1_user.a.id, ns1_user.b.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserOnTeam("ns1_user.a", "ns1_team.t", true),
+					testAccCheckUserOnTeam("ns1_user.b", "ns1_team.t", true),
+				),
+			},
+			{
+				ResourceName:      `ns1_team_membership.it["tf_acc_test_user_a_` + rString + `"]`,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "ns1_team_membership.key",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccTeamMembership(rString, `[ns1_user.a.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserOnTeam("ns1_user.a", "ns1_team.t", true),
+					testAccCheckUserOnTeam("ns1_user.b", "ns1_team.t", false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTeamMembers_basic(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTeamDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamMembers(rString, `[ns1_user.a.id, ns1_user.b.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ns1_team_members.it", "users.#", "2"),
+					resource.TestCheckResourceAttr("ns1_team_members.it", "apikeys.#", "1"),
+				),
+			},
+			// A member added outside of the resource shows as drift.
+			{
+				PreConfig:          testAccAddUserToTeam(fmt.Sprintf("tf_acc_test_user_c_%s", rString), fmt.Sprintf("terraform acc test team %s", rString)),
+				Config:             testAccTeamMembers(rString, `[ns1_user.a.id, ns1_user.b.id]`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccTeamMembers(rString, `[ns1_user.a.id, ns1_user.b.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ns1_team_members.it", "users.#", "2"),
+					testAccCheckUserOnTeam("ns1_user.c", "ns1_team.t", false),
+				),
+			},
+			{
+				ResourceName:      "ns1_team_members.it",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Leaving its last team must not give a user the permissions of the team.
+func TestAccTeamMembership_keepsOwnPermissions(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTeamDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamMembership(rString, `[ns1_user.a.id]`),
+			},
+			{
+				Config: testAccTeamMembership(rString, `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserOnTeam("ns1_user.a", "ns1_team.t", false),
+					resource.TestCheckResourceAttr("ns1_user.a", "effective_permissions.0.account.0.manage_account_settings", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestTeamMembershipImportStateFunc(t *testing.T) {
+	d := teamMembershipResource().Data(nil)
+	d.SetId("team/apikey/key")
+	_, err := teamMembershipImportStateFunc(d, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "team", d.Get("team_id"))
+	assert.Equal(t, "key", d.Get("apikey"))
+	assert.Equal(t, "", d.Get("user"))
+
+	d.SetId("team/alice")
+	_, err = teamMembershipImportStateFunc(d, nil)
+	assert.Error(t, err)
+}
+
+func testAccCheckUserOnTeam(user, team string, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		u, ok := s.RootModule().Resources[user]
+		if !ok {
+			return fmt.Errorf("not found: %s", user)
+		}
+		t, ok := s.RootModule().Resources[team]
+		if !ok {
+			return fmt.Errorf("not found: %s", team)
+		}
+
+		client := testAccProvider.Meta().(*ns1.Client)
+		found, _, err := client.Users.Get(u.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if containsString(found.TeamIDs, t.Primary.ID) != expected {
+			return fmt.Errorf("user %s on team %s: got: %t want: %t", u.Primary.ID, t.Primary.ID, !expected, expected)
+		}
+		return nil
+	}
+}
+
+// Simulate adding a user to a team outside of Terraform.
+func testAccAddUserToTeam(username, teamName string) func() {
+	return func() {
+		client := testAccProvider.Meta().(*ns1.Client)
+		teams, _, err := client.Teams.List()
+		if err != nil {
+			panic(err)
+		}
+		for _, t := range teams {
+			if t.Name == teamName {
+				if err := setUserTeam(client, username, t.ID, true); err != nil {
+					panic(err)
+				}
+				return
+			}
+		}
+		panic(fmt.Sprintf("team %q not found", teamName))
+	}
+}
+
+const testAccTeamMembersBase = `resource "ns1_team" "t" {
+  name = "terraform acc test team %[1]s"
+
+  permissions {
+    account {
+      manage_account_settings = true
+    }
+  }
+}
+
+resource "ns1_user" "a" {
+  name     = "terraform acc test user a %[1]s"
+  username = "tf_acc_test_user_a_%[1]s"
+  email    = "tf_acc_test_ns1@hashicorp.com"
+
+  lifecycle {
+    ignore_changes = [teams]
+  }
+}
+
+resource "ns1_user" "b" {
+  name     = "terraform acc test user b %[1]s"
+  username = "tf_acc_test_user_b_%[1]s"
+  email    = "tf_acc_test_ns1@hashicorp.com"
+
+  lifecycle {
+    ignore_changes = [teams]
+  }
+}
+
+resource "ns1_user" "c" {
+  name     = "terraform acc test user c %[1]s"
+  username = "tf_acc_test_user_c_%[1]s"
+  email    = "tf_acc_test_ns1@hashicorp.com"
+
+  lifecycle {
+    ignore_changes = [teams]
+  }
+}
+
+resource "ns1_apikey" "k" {
+  name = "terraform acc test key %[1]s"
+
+  lifecycle {
+    ignore_changes = [teams]
+  }
+}
+`
+
+func testAccTeamMembership(rString, users string) string {
+	return fmt.Sprintf(testAccTeamMembersBase+`
+resource "ns1_team_membership" "it" {
+  for_each = toset(%[2]s)
+  team_id  = ns1_team.t.id
+  user     = each.value
+}
+
+resource "ns1_team_membership" "key" {
+  team_id = ns1_team.t.id
+  apikey  = ns1_apikey.k.id
+}
+`, rString, users)
+}
+
+func testAccTeamMembers(rString, users string) string {
+	return fmt.Sprintf(testAccTeamMembersBase+`
+resource "ns1_team_members" "it" {
+  team_id = ns1_team.t.id
+  users   = %[2]s
+  apikeys = [ns1_apikey.k.id]
+}
+`, rString, users)
+}
@@ -20,3 +20,39 @@ func teamResourceV0() *schema.Resource {
 		Delete: TeamDelete,
 	}
 }
+
+func teamResourceV1() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"ip_whitelist": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"values": {
+						Type:     schema.TypeSet,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+						Required: true,
+					},
+				},
+			},
+		},
+	}
+
+	s = addPermsSchemaV1(s)
+
+	return &schema.Resource{
+		Schema: s,
+		Create: TeamCreate,
+		Read:   TeamRead,
+		Update: TeamUpdate,
+		Delete: TeamDelete,
+	}
+}
@@ -116,7 +116,7 @@ func TestAccTeam_ManualDelete(t *testing.T) {
 func TestAccTeam_import_test(t *testing.T) {
 	var team account.Team
 	n := fmt.Sprintf("terraform test team %s", acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum))
-	ignored_fields := []string{"dns_records"}
+	ignored_fields := []string{"permissions.0.dns.0.records_allow", "permissions.0.dns.0.records_deny"}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -147,7 +147,7 @@ func TestAccTeam_import_test(t *testing.T) {
 				ResourceName:      "ns1_team.foobar",
 				ImportState:       true,
 				ImportStateVerify: true,
-				// Ignoring some fields because of how the dns_records work right now
+				// Ignoring some fields because of how the dns records work right now
 				ImportStateVerifyIgnore: ignored_fields,
 			},
 		},
@@ -180,9 +180,16 @@ func testAccTeamEmptyIPWhitelist(name string) string {
 	return fmt.Sprintf(`
 resource "ns1_team" "foobar" {
   name = "%s"
-  
-  dns_view_zones = true
-  data_manage_datasources = true
+
+  permissions {
+    dns {
+      view_zones = true
+    }
+
+    data {
+      manage_datasources = true
+    }
+  }
 }
 `, name)
 }
@@ -381,13 +388,6 @@ const testAccTeamBasic = `
 resource "ns1_team" "foobar" {
   name = "%s"
 
-  dns_view_zones = true
-  dns_zones_allow_by_default = true
-  dns_zones_allow = ["mytest.zone"]
-  dns_zones_deny = ["myother.zone"]
-
-  data_manage_datasources = true
-
   ip_whitelist {
 	name = "whitelist-1"
 	values = ["1.1.1.1", "2.2.2.2"]
@@ -398,28 +398,44 @@ resource "ns1_team" "foobar" {
 	values = ["3.3.3.3", "4.4.4.4"]
   }
 
-  dns_records_allow {
-	domain = "my.ns1.com"
-	include_subdomains = false
-	zone = "ns1.com"
-	type = "A"
-}
-
-  dns_records_deny {
-	domain = "my.test.com"
-	include_subdomains = true
-	zone = "test.com"
-	type = "A"
- }
-
+  permissions {
+    dns {
+      view_zones             = true
+      zones_allow_by_default = true
+      zones_allow            = ["mytest.zone"]
+      zones_deny             = ["myother.zone"]
+      records_allow {
+        domain             = "my.ns1.com"
+        include_subdomains = false
+        zone               = "ns1.com"
+        type               = "A"
+      }
+      records_deny {
+        domain             = "my.test.com"
+        include_subdomains = true
+        zone               = "test.com"
+        type               = "A"
+      }
+    }
+
+    data {
+      manage_datasources = true
+    }
+  }
 }`
 
 const testAccTeamUpdated = `
 resource "ns1_team" "foobar" {
   name = "%s updated"
 
-  dns_view_zones = true
-  dns_zones_allow_by_default = true
+  permissions {
+    dns {
+      view_zones             = true
+      zones_allow_by_default = true
+    }
 
-  data_manage_datasources = false
+    data {
+      manage_datasources = false
+    }
+  }
 }`
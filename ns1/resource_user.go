@@ -1,10 +1,15 @@
 package ns1
 
 import (
+	"context"
+	"encoding/xml"
 	"fmt"
+	"io"
 	"log"
 	"regexp"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
@@ -41,19 +46,49 @@ func userResource() *schema.Resource {
 			Optional: true,
 			Elem:     &schema.Schema{Type: schema.TypeString},
 		},
-		"ip_whitelist": {
-			Type:     schema.TypeSet,
-			Optional: true,
-			Elem:     &schema.Schema{Type: schema.TypeString},
-		},
+		"ip_whitelist": ipWhitelistSchema(false),
 		"ip_whitelist_strict": {
 			Type:     schema.TypeBool,
 			Optional: true,
 			Default:  false,
 		},
+		"saml": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Computed: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"sso": {
+						Type:     schema.TypeBool,
+						Required: true,
+					},
+					"provider": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"metadata_url": {
+						Type:          schema.TypeString,
+						Optional:      true,
+						ConflictsWith: []string{"saml.0.metadata_xml"},
+					},
+					"metadata_xml": {
+						Type:          schema.TypeString,
+						Optional:      true,
+						ConflictsWith: []string{"saml.0.metadata_url"},
+						ValidateFunc:  validateSAMLMetadata,
+					},
+				},
+			},
+		},
+		"two_factor_auth_enabled": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
 	}
 
-	s = addPermsSchema(s)
+	s = addMemberPermsSchema(s)
+	s = addRecordsACLSchema(s)
 
 	return &schema.Resource{
 		Schema:        s,
@@ -62,18 +97,24 @@ func userResource() *schema.Resource {
 		Update:        UserUpdate,
 		Delete:        UserDelete,
 		Importer:      &schema.ResourceImporter{State: userImportStateFunc},
-		SchemaVersion: 1,
+		CustomizeDiff: customdiff.All(recordsACLCustomizeDiff, samlCustomizeDiff),
+		SchemaVersion: 2,
 		StateUpgraders: []schema.StateUpgrader{
 			{
 				Type:    userResourceV0().CoreConfigSchema().ImpliedType(),
 				Upgrade: permissionInstanceStateUpgradeV0,
 				Version: 0,
 			},
+			{
+				Type:    userResourceV1().CoreConfigSchema().ImpliedType(),
+				Upgrade: permissionInstanceStateUpgradeV1,
+				Version: 1,
+			},
 		},
 	}
 }
 
-func userToResourceData(d *schema.ResourceData, u *account.User) error {
+func userToResourceData(d *schema.ResourceData, u *account.User, client *ns1.Client) error {
 	d.SetId(u.Username)
 	d.Set("username", u.Username)
 	d.Set("name", u.Name)
@@ -81,8 +122,14 @@ func userToResourceData(d *schema.ResourceData, u *account.User) error {
 	d.Set("teams", u.TeamIDs)
 	d.Set("ip_whitelist", u.IPWhitelist)
 	d.Set("ip_whitelist_strict", u.IPWhitelistStrict)
-	permissionsToResourceData(d, u.Permissions)
-	return nil
+	d.Set("two_factor_auth_enabled", u.TwoFactorAuthEnabled)
+	if err := d.Set("saml", samlToSchema(u.SharedAuth.SAML)); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting saml for: %s, error: %#v", u.Username, err)
+	}
+	if err := memberPermissionsToResourceData(d, client, u.TeamIDs, u.Permissions); err != nil {
+		return err
+	}
+	return recordsACLMatchesToResourceData(d, client)
 }
 
 func resourceDataToUser(u *account.User, d *schema.ResourceData) error {
@@ -99,24 +146,109 @@ func resourceDataToUser(u *account.User, d *schema.ResourceData) error {
 		u.TeamIDs = make([]string, 0)
 	}
 
-	if v, ok := d.GetOk("ip_whitelist"); ok {
-		ipWhitelistRaw := v.(*schema.Set)
-		u.IPWhitelist = make([]string, ipWhitelistRaw.Len())
-		for i, ip := range ipWhitelistRaw.List() {
-			u.IPWhitelist[i] = ip.(string)
-		}
-	} else {
-		// This still needs to be initialized to a zero value,
-		// otherwise it can't be removed.
-		u.IPWhitelist = make([]string, 0)
-	}
+	// This is never nil, otherwise the whitelist can't be removed.
+	u.IPWhitelist = schemaToIPWhitelist(d.Get("ip_whitelist"))
 
 	u.IPWhitelistStrict = d.Get("ip_whitelist_strict").(bool)
 
+	u.SharedAuth.SAML = schemaToSAML(d.Get("saml").([]interface{}))
+
 	u.Permissions = resourceDataToPermissions(d)
 	return nil
 }
 
+func samlToSchema(s account.SAML) []interface{} {
+	m := map[string]interface{}{
+		"sso":          s.SSO,
+		"provider":     "",
+		"metadata_url": "",
+		"metadata_xml": "",
+	}
+	if s.Provider != nil {
+		m["provider"] = *s.Provider
+	}
+	if s.UseMetadataURL != nil && *s.UseMetadataURL {
+		if s.MetadataURL != nil {
+			m["metadata_url"] = *s.MetadataURL
+		}
+	} else if s.MetadataFile != nil {
+		m["metadata_xml"] = *s.MetadataFile
+	}
+	return []interface{}{m}
+}
+
+// schemaToSAML builds the SAML settings of a user. Without a saml block
+// the user does not use SSO.
+func schemaToSAML(v []interface{}) account.SAML {
+	if len(v) == 0 || v[0] == nil {
+		return account.SAML{}
+	}
+
+	m := v[0].(map[string]interface{})
+	s := account.SAML{SSO: m["sso"].(bool)}
+	if p := m["provider"].(string); p != "" {
+		s.Provider = &p
+	}
+	url, file := m["metadata_url"].(string), m["metadata_xml"].(string)
+	useURL := url != ""
+	switch {
+	case useURL:
+		s.UseMetadataURL = &useURL
+		s.MetadataURL = &url
+	case file != "":
+		s.UseMetadataURL = &useURL
+		s.MetadataFile = &file
+	}
+	return s
+}
+
+// samlCustomizeDiff checks that a user with sso has identity provider
+// metadata.
+func samlCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("saml") {
+		return nil
+	}
+	saml, _ := d.Get("saml").([]interface{})
+	if len(saml) == 0 || saml[0] == nil {
+		return nil
+	}
+	m := saml[0].(map[string]interface{})
+	if !m["sso"].(bool) {
+		return nil
+	}
+	if !d.NewValueKnown("saml.0.metadata_url") || !d.NewValueKnown("saml.0.metadata_xml") {
+		return nil
+	}
+	if m["metadata_url"].(string) == "" && m["metadata_xml"].(string) == "" {
+		return fmt.Errorf("saml: sso requires one of metadata_url or metadata_xml")
+	}
+	return nil
+}
+
+// validateSAMLMetadata checks that the identity provider metadata is well
+// formed XML with an EntityDescriptor root.
+func validateSAMLMetadata(val interface{}, key string) (warns []string, errs []error) {
+	dec := xml.NewDecoder(strings.NewReader(val.(string)))
+	root := ""
+	for {
+		t, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s is not valid XML: %s", key, err))
+			return warns, errs
+		}
+		if se, ok := t.(xml.StartElement); ok && root == "" {
+			root = se.Name.Local
+		}
+	}
+	if root != "EntityDescriptor" && root != "EntitiesDescriptor" {
+		errs = append(errs, fmt.Errorf("%s must be SAML metadata with an EntityDescriptor root element, got %q", key, root))
+	}
+	return warns, errs
+}
+
 // UserCreate creates the given user in ns1
 func UserCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
@@ -124,22 +256,13 @@ func UserCreate(d *schema.ResourceData, meta interface{}) error {
 	if err := resourceDataToUser(&u, d); err != nil {
 		return err
 	}
+	if err := verifyRecordsACL(d, client); err != nil {
+		return err
+	}
 	if resp, err := client.Users.Create(&u); err != nil {
 		return ConvertToNs1Error(resp, err)
 	}
-
-	// If a user is assigned to at least one team, then it's permissions need to be refreshed
-	// because the current user permissions in Terraform state will be out of date.
-	if len(u.TeamIDs) > 0 {
-		updatedUser, resp, err := client.Users.Get(u.Username)
-		if err != nil {
-			return ConvertToNs1Error(resp, err)
-		}
-
-		return userToResourceData(d, updatedUser)
-	}
-
-	return userToResourceData(d, &u)
+	return userToResourceData(d, &u, client)
 }
 
 // UserRead reads the given users data from ns1
@@ -155,7 +278,7 @@ func UserRead(d *schema.ResourceData, meta interface{}) error {
 
 		return ConvertToNs1Error(resp, err)
 	}
-	return userToResourceData(d, u)
+	return userToResourceData(d, u, client)
 }
 
 // UserDelete deletes the given user from ns1
@@ -176,22 +299,13 @@ func UserUpdate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	if err := verifyRecordsACL(d, client); err != nil {
+		return err
+	}
 	if resp, err := client.Users.Update(&u); err != nil {
 		return ConvertToNs1Error(resp, err)
 	}
-
-	// If a user's teams has changed then the permissions on the user need to be refreshed
-	// because the current user permissions in Terraform state will be out of date.
-	if d.HasChange("teams") {
-		updatedUser, resp, err := client.Users.Get(d.Id())
-		if err != nil {
-			return ConvertToNs1Error(resp, err)
-		}
-
-		return userToResourceData(d, updatedUser)
-	}
-
-	return userToResourceData(d, &u)
+	return userToResourceData(d, &u, client)
 }
 
 func validateUsername(
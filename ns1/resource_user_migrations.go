@@ -39,3 +39,53 @@ func userResourceV0() *schema.Resource {
 		Delete: UserDelete,
 	}
 }
+
+func userResourceV1() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"username": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"email": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"notify": {
+			Deprecated: "This field is deprecated and will be removed in a future release; create account usage alerts instead.",
+			Type:       schema.TypeMap,
+			Optional:   true,
+			Computed:   true,
+			Elem:       schema.TypeBool,
+		},
+		"teams": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"ip_whitelist": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"ip_whitelist_strict": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	}
+
+	s = addPermsSchemaV1(s)
+
+	return &schema.Resource{
+		Schema: s,
+		Create: UserCreate,
+		Read:   UserRead,
+		Update: UserUpdate,
+		Delete: UserDelete,
+	}
+}
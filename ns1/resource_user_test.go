@@ -1,6 +1,7 @@
 package ns1
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"reflect"
@@ -100,11 +101,11 @@ func TestAccUser_permissions(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "redirects_manage_redirects", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "insights_view_insights", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "insights_manage_insights", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_account_settings", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_ip_whitelist", "true"),
+					testAccCheckPermissionAttr("ns1_user.u", "redirects.manage_redirects", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "insights.view_insights", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "insights.manage_insights", "false"),
 				),
 			},
 			{
@@ -114,13 +115,13 @@ func TestAccUser_permissions(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "security_manage_global_2fa", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "security_manage_active_directory", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "redirects_manage_redirects", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "insights_view_insights", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "insights_manage_insights", "true"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_account_settings", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_ip_whitelist", "true"),
+					testAccCheckPermissionAttr("ns1_user.u", "security.manage_global_2fa", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "security.manage_active_directory", "true"),
+					testAccCheckPermissionAttr("ns1_user.u", "redirects.manage_redirects", "true"),
+					testAccCheckPermissionAttr("ns1_user.u", "insights.view_insights", "true"),
+					testAccCheckPermissionAttr("ns1_user.u", "insights.manage_insights", "true"),
 				),
 			},
 			{
@@ -130,11 +131,11 @@ func TestAccUser_permissions(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "redirects_manage_redirects", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "insights_view_insights", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "insights_manage_insights", "false"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_account_settings", "true"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_ip_whitelist", "false"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.redirects.0.manage_redirects", "false"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.insights.0.view_insights", "false"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.insights.0.manage_insights", "false"),
 				),
 			},
 			{
@@ -144,22 +145,8 @@ func TestAccUser_permissions(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					// The user should still have this permission, it would have inherited it from the team.
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "false"),
-				),
-				ExpectNonEmptyPlan: true,
-			},
-			{
-				Config: testAccUserPermissionsNoTeam(rString),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckUserExists("ns1_user.u", &user),
-					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
-					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
-					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					// But if an apply is ran again, the permission will be removed.
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "true"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_account_settings", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_ip_whitelist", "true"),
 				),
 			},
 		},
@@ -184,8 +171,8 @@ func TestAccUser_permissions_empty_team(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "false"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_account_settings", "true"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_ip_whitelist", "false"),
 				),
 			},
 			// Strange Terraform behavior causes explicitly settings a users team to []
@@ -197,10 +184,9 @@ func TestAccUser_permissions_empty_team(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_account_settings", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_ip_whitelist", "false"),
 				),
-				ExpectNonEmptyPlan: true,
 			},
 			{
 				Config: testAccUserPermissionsNoTeam(rString),
@@ -209,9 +195,8 @@ func TestAccUser_permissions_empty_team(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					// But if an apply is ran again, the permission will be removed.
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "true"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_account_settings", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_ip_whitelist", "true"),
 				),
 			},
 		},
@@ -237,8 +222,8 @@ func TestAccUser_permissions_start_no_team(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "true"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_account_settings", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_ip_whitelist", "true"),
 				),
 			},
 			{
@@ -248,22 +233,9 @@ func TestAccUser_permissions_start_no_team(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "false"),
-				),
-			},
-			{
-				Config: testAccUserPermissionsNoTeam(rString),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckUserExists("ns1_user.u", &user),
-					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
-					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
-					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					// The user should still have this permission, it would have inherited it from the team.
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "false"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_account_settings", "true"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_ip_whitelist", "false"),
 				),
-				ExpectNonEmptyPlan: true,
 			},
 			{
 				Config: testAccUserPermissionsNoTeam(rString),
@@ -272,9 +244,8 @@ func TestAccUser_permissions_start_no_team(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					// But if an apply is ran again, the permission will be removed.
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "true"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_account_settings", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_ip_whitelist", "true"),
 				),
 			},
 		},
@@ -300,8 +271,8 @@ func TestAccUser_permissions_multiple_teams(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "false"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_account_settings", "true"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_ip_whitelist", "false"),
 				),
 			},
 			{
@@ -311,9 +282,9 @@ func TestAccUser_permissions_multiple_teams(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_apikeys", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "true"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_account_settings", "true"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_apikeys", "true"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_ip_whitelist", "true"),
 				),
 			},
 			{
@@ -323,9 +294,9 @@ func TestAccUser_permissions_multiple_teams(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_apikeys", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "false"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_account_settings", "true"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_apikeys", "false"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_ip_whitelist", "false"),
 				),
 			},
 			{
@@ -335,12 +306,10 @@ func TestAccUser_permissions_multiple_teams(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					// The user should still have this permission, it would have inherited it from the team.
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_apikeys", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_account_settings", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_apikeys", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_ip_whitelist", "false"),
 				),
-				ExpectNonEmptyPlan: true,
 			},
 			{
 				Config: testAccUserPermissionsNoTeam(rString),
@@ -349,10 +318,9 @@ func TestAccUser_permissions_multiple_teams(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					// But if an apply is ran again, the permission will be removed.
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_apikeys", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "true"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_account_settings", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_apikeys", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_ip_whitelist", "true"),
 				),
 			},
 		},
@@ -378,8 +346,8 @@ func TestAccUser_permissions_multiple_teams_start_no_team(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "true"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_account_settings", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_ip_whitelist", "true"),
 				),
 			},
 			{
@@ -389,9 +357,9 @@ func TestAccUser_permissions_multiple_teams_start_no_team(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_apikeys", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "true"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_account_settings", "true"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_apikeys", "true"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_ip_whitelist", "true"),
 				),
 			},
 			{
@@ -401,9 +369,9 @@ func TestAccUser_permissions_multiple_teams_start_no_team(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_apikeys", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "false"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_account_settings", "true"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_apikeys", "false"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_ip_whitelist", "false"),
 				),
 			},
 			{
@@ -413,12 +381,10 @@ func TestAccUser_permissions_multiple_teams_start_no_team(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					// The user should still have this permission, it would have inherited it from the team.
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_apikeys", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_account_settings", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_apikeys", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_ip_whitelist", "false"),
 				),
-				ExpectNonEmptyPlan: true,
 			},
 			{
 				Config: testAccUserPermissionsNoTeam(rString),
@@ -427,10 +393,9 @@ func TestAccUser_permissions_multiple_teams_start_no_team(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					// But if an apply is ran again, the permission will be removed.
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_apikeys", "false"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_ip_whitelist", "true"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_account_settings", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_apikeys", "false"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_ip_whitelist", "true"),
 				),
 			},
 		},
@@ -524,12 +489,6 @@ resource "ns1_user" "u" {
 }
 
 // Case when a user is on a team and that team updates it's permissions.
-// This test is currently failing, as this is not implemented yet - this doesn't
-// actually cause any issues because it's just Terraforms state that doesn't have the
-// new permission values yet, the backend does, and when `terraform refresh` is ran,
-// the state will be updated appropriately.
-// The test is left here for documentation purposes.
-/*
 func TestAccUser_permissions_team_update(t *testing.T) {
 	var user account.User
 	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
@@ -548,7 +507,7 @@ func TestAccUser_permissions_team_update(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "true"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_account_settings", "true"),
 				),
 			},
 			{
@@ -558,8 +517,16 @@ func TestAccUser_permissions_team_update(t *testing.T) {
 					resource.TestCheckResourceAttr("ns1_user.u", "email", "tf_acc_test_ns1@hashicorp.com"),
 					resource.TestCheckResourceAttr("ns1_user.u", "name", name),
 					resource.TestCheckResourceAttr("ns1_user.u", "username", username),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_account_settings", "true"),
-					resource.TestCheckResourceAttr("ns1_user.u", "account_manage_apikeys", "true"),
+				),
+			},
+			// The user is not changed by the apply, its effective permissions
+			// pick up the change to the team when it is next read.
+			{
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_account_settings", "true"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.account.0.manage_apikeys", "true"),
+					testAccCheckPermissionAttr("ns1_user.u", "account.manage_apikeys", "false"),
 				),
 			},
 		},
@@ -569,8 +536,13 @@ func TestAccUser_permissions_team_update(t *testing.T) {
 func testAccUserPermissionsTeamUpdate(rString string) string {
 	return fmt.Sprintf(`resource "ns1_team" "t" {
   name = "terraform acc test team %s"
-  account_manage_account_settings = true
-  account_manage_apikeys = true
+
+  permissions {
+    account {
+      manage_account_settings = true
+      manage_apikeys          = true
+    }
+  }
 }
 
 resource "ns1_user" "u" {
@@ -586,7 +558,36 @@ resource "ns1_user" "u" {
 }
 `, rString, rString, rString)
 }
-*/
+
+func TestAccUser_saml(t *testing.T) {
+	var user account.User
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserSAML(rString, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserExists("ns1_user.u", &user),
+					resource.TestCheckResourceAttr("ns1_user.u", "saml.0.sso", "true"),
+					resource.TestCheckResourceAttr("ns1_user.u", "saml.0.provider", "okta"),
+					resource.TestCheckResourceAttr("ns1_user.u", "saml.0.metadata_url", "https://idp.example.com/metadata"),
+					resource.TestCheckResourceAttr("ns1_user.u", "two_factor_auth_enabled", "false"),
+				),
+			},
+			{
+				Config: testAccUserSAML(rString, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserExists("ns1_user.u", &user),
+					resource.TestCheckResourceAttr("ns1_user.u", "saml.0.sso", "false"),
+				),
+			},
+		},
+	})
+}
 
 func TestValidateUsername(t *testing.T) {
 	tests := []struct {
@@ -620,6 +621,92 @@ func TestValidateUsername(t *testing.T) {
 	}
 }
 
+func TestValidateSAMLMetadata(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		expErrs int
+	}{
+		{
+			"valid",
+			`<?xml version="1.0"?><md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://idp.example.com"/>`,
+			0,
+		},
+		{
+			"invalid - not xml",
+			"https://idp.example.com/metadata",
+			1,
+		},
+		{
+			"invalid - unclosed",
+			`<EntityDescriptor entityID="https://idp.example.com">`,
+			1,
+		},
+		{
+			"invalid - root",
+			`<html></html>`,
+			1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outWarns, outErrs := validateSAMLMetadata(tt.in, "metadata_xml")
+			assert.Equal(t, tt.expErrs, len(outErrs))
+			assert.Equal(t, 0, len(outWarns))
+		})
+	}
+}
+
+func TestSAML(t *testing.T) {
+	assert.Equal(t, account.SAML{}, schemaToSAML(nil))
+
+	in := []interface{}{map[string]interface{}{
+		"sso": true, "provider": "okta", "metadata_url": "https://idp.example.com/metadata", "metadata_xml": "",
+	}}
+	s := schemaToSAML(in)
+	assert.True(t, *s.UseMetadataURL)
+	assert.Nil(t, s.MetadataFile)
+	assert.Equal(t, in, samlToSchema(s))
+
+	in = []interface{}{map[string]interface{}{
+		"sso": true, "provider": "", "metadata_url": "", "metadata_xml": "<EntityDescriptor/>",
+	}}
+	s = schemaToSAML(in)
+	assert.False(t, *s.UseMetadataURL)
+	assert.Nil(t, s.Provider)
+	assert.Equal(t, in, samlToSchema(s))
+}
+
+func TestSAMLCustomizeDiff(t *testing.T) {
+	r := userResource()
+	config := map[string]interface{}{
+		"name":     "user",
+		"username": "user",
+		"email":    "user@example.com",
+		"saml": []interface{}{map[string]interface{}{
+			"sso": true,
+		}},
+	}
+	_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "saml: sso requires one of metadata_url or metadata_xml")
+	}
+
+	config["saml"] = []interface{}{map[string]interface{}{
+		"sso":          true,
+		"metadata_url": "https://idp.example.com/metadata",
+	}}
+	_, err = r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+	assert.NoError(t, err)
+
+	config["saml"] = []interface{}{map[string]interface{}{
+		"sso": false,
+	}}
+	_, err = r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+	assert.NoError(t, err)
+}
+
 func testAccCheckUserDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*ns1.Client)
 
@@ -693,7 +780,11 @@ func testAccUserBasic(rString string) string {
 	return fmt.Sprintf(`resource "ns1_team" "t" {
   name = "terraform acc test team %s"
 
-  account_view_invoices = true
+  permissions {
+    account {
+      view_invoices = true
+    }
+  }
 }
 
 resource "ns1_user" "u" {
@@ -714,7 +805,11 @@ func testAccUserUpdated(rString string) string {
 	return fmt.Sprintf(`resource "ns1_team" "t" {
   name = "terraform acc test team %s"
 
-  account_view_invoices = true
+  permissions {
+    account {
+      view_invoices = true
+    }
+  }
 }
 
 resource "ns1_user" "u" {
@@ -732,8 +827,13 @@ resource "ns1_user" "u" {
 func testAccUserPermissionsOnTeam(rString string) string {
 	return fmt.Sprintf(`resource "ns1_team" "t" {
   name = "terraform acc test team %s"
-  account_manage_account_settings = true
-  account_manage_ip_whitelist = false
+
+  permissions {
+    account {
+      manage_account_settings = true
+      manage_ip_whitelist     = false
+    }
+  }
 }
 
 resource "ns1_user" "u" {
@@ -753,7 +853,12 @@ resource "ns1_user" "u" {
 func testAccUserPermissionsNoTeam(rString string) string {
 	return fmt.Sprintf(`resource "ns1_team" "t" {
   name = "terraform acc test team %s"
-  account_manage_account_settings = true
+
+  permissions {
+    account {
+      manage_account_settings = true
+    }
+  }
 }
 
 resource "ns1_user" "u" {
@@ -765,7 +870,11 @@ resource "ns1_user" "u" {
     billing = false
   }
 
-  account_manage_ip_whitelist = true
+  permissions {
+    account {
+      manage_ip_whitelist = true
+    }
+  }
 }
 `, rString, rString, rString)
 }
@@ -773,7 +882,12 @@ resource "ns1_user" "u" {
 func testAccUserSecurityPermissionsNoTeam(rString string) string {
 	return fmt.Sprintf(`resource "ns1_team" "t" {
   name = "terraform acc test team %s"
-  account_manage_account_settings = true
+
+  permissions {
+    account {
+      manage_account_settings = true
+    }
+  }
 }
 
 resource "ns1_user" "u" {
@@ -785,11 +899,24 @@ resource "ns1_user" "u" {
     billing = false
   }
 
-  account_manage_ip_whitelist = true
-  security_manage_global_2fa = false
-  redirects_manage_redirects = true
-  insights_view_insights = true
-  insights_manage_insights = true
+  permissions {
+    account {
+      manage_ip_whitelist = true
+    }
+
+    security {
+      manage_global_2fa = false
+    }
+
+    redirects {
+      manage_redirects = true
+    }
+
+    insights {
+      view_insights   = true
+      manage_insights = true
+    }
+  }
 }
 `, rString, rString, rString)
 }
@@ -798,7 +925,12 @@ resource "ns1_user" "u" {
 func testAccUserPermissionsEmptyTeam(rString string) string {
 	return fmt.Sprintf(`resource "ns1_team" "t" {
   name = "terraform acc test team %s"
-  account_manage_account_settings = true
+
+  permissions {
+    account {
+      manage_account_settings = true
+    }
+  }
 }
 
 resource "ns1_user" "u" {
@@ -818,13 +950,23 @@ resource "ns1_user" "u" {
 func testAccUserPermissionsOnTwoTeam(rString string) string {
 	return fmt.Sprintf(`resource "ns1_team" "t" {
   name = "terraform acc test team %s"
-  account_manage_account_settings = true
+
+  permissions {
+    account {
+      manage_account_settings = true
+    }
+  }
 }
 
 resource "ns1_team" "t2" {
   name = "terraform acc test team %s-2"
-  account_manage_apikeys = true
-  account_manage_ip_whitelist = true
+
+  permissions {
+    account {
+      manage_apikeys      = true
+      manage_ip_whitelist = true
+    }
+  }
 }
 
 resource "ns1_user" "u" {
@@ -838,7 +980,26 @@ resource "ns1_user" "u" {
     billing = false
   }
 
-  account_manage_ip_whitelist = true
+  permissions {
+    account {
+      manage_ip_whitelist = true
+    }
+  }
 }
 `, rString, rString, rString, rString)
 }
+
+func testAccUserSAML(rString string, sso bool) string {
+	return fmt.Sprintf(`resource "ns1_user" "u" {
+  name     = "terraform acc test user %[1]s"
+  username = "tf_acc_test_user_%[1]s"
+  email    = "tf_acc_test_ns1@hashicorp.com"
+
+  saml {
+    sso          = %[2]t
+    provider     = "okta"
+    metadata_url = "https://idp.example.com/metadata"
+  }
+}
+`, rString, sso)
+}